@@ -0,0 +1,576 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// errPreconditionFailed is returned by conditional writes the backend rejected
+// with 412 Precondition Failed because the object changed since it was read.
+var errPreconditionFailed = errors.New("precondition failed")
+
+const (
+	// defaultEndpoint is used when the provider endpoint is not configured.
+	defaultEndpoint = "http://localhost:8080"
+
+	// defaultPageSize is the number of items requested per page from list endpoints.
+	defaultPageSize = 100
+)
+
+// DevopsClient is the client handed to resources and data sources. It embeds
+// the HTTP client and carries the provider level settings.
+type DevopsClient struct {
+	*http.Client
+
+	Endpoint   string
+	PageSize   int64
+	MaxResults int64
+
+	// AdoptExistingEngineers is the default for the engineer adopt_existing
+	// attribute.
+	AdoptExistingEngineers bool
+
+	// ReadOnly is set when resources must not change the backend. The HTTP
+	// client of a read-only provider also refuses anything but GET and HEAD.
+	ReadOnly bool
+
+	// capabilities caches the features the backend advertises.
+	capabilities *capabilityCache
+}
+
+// NewDevopsClient returns a client for the given endpoint with default settings.
+func NewDevopsClient(httpClient *http.Client, endpoint string) *DevopsClient {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+
+	return &DevopsClient{
+		Client:   httpClient,
+		Endpoint: strings.TrimRight(endpoint, "/"),
+		PageSize: defaultPageSize,
+
+		capabilities: &capabilityCache{},
+	}
+}
+
+// URL joins the given path onto the configured endpoint.
+func (c *DevopsClient) URL(path string) string {
+	return c.Endpoint + path
+}
+
+// unbounded returns a copy of the client without the MaxResults cap, for
+// lookups that must see every item.
+func (c *DevopsClient) unbounded() *DevopsClient {
+	clone := *c
+	clone.MaxResults = 0
+
+	return &clone
+}
+
+// capResults trims filtered items to MaxResults. Callers that filter client
+// side fetch with an unbounded client and cap the matches instead, so the cap
+// does not hide matches beyond the first MaxResults items.
+func capResults[T any](c *DevopsClient, items []T) []T {
+	if c.MaxResults > 0 && int64(len(items)) > c.MaxResults {
+		return items[:c.MaxResults]
+	}
+
+	return items
+}
+
+// listPage is the envelope returned by paginated list endpoints.
+type listPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// listAll fetches every item from a list endpoint, following the cursor until
+// the backend reports no further pages or MaxResults is reached. Backends that
+// do not paginate and return a plain JSON array are handled as a single page.
+func listAll[T any](ctx context.Context, c *DevopsClient, path string) ([]T, error) {
+	var items []T
+	cursor := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.FormatInt(c.pageLimit(len(items)), 10))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		pageURL := c.URL(path) + "?" + query.Encode()
+
+		tflog.Debug(ctx, "Fetching list page", map[string]interface{}{"url": pageURL})
+
+		httpResp, err := c.Get(pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d from %s: %s", httpResp.StatusCode, path, string(bodyBytes))
+		}
+
+		page, err := decodeListPage[T](bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Items...)
+
+		if c.MaxResults > 0 && int64(len(items)) >= c.MaxResults {
+			return items[:c.MaxResults], nil
+		}
+
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return items, nil
+		}
+
+		cursor = page.NextCursor
+	}
+}
+
+// pageLimit returns the limit for the next page, never asking for more than
+// the remaining MaxResults.
+func (c *DevopsClient) pageLimit(fetched int) int64 {
+	limit := c.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	if c.MaxResults > 0 {
+		remaining := c.MaxResults - int64(fetched)
+		if remaining < limit {
+			limit = remaining
+		}
+	}
+
+	return limit
+}
+
+// decodeListPage accepts either a paginated envelope or a plain JSON array.
+func decodeListPage[T any](body []byte) (listPage[T], error) {
+	var page listPage[T]
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		err := json.Unmarshal(body, &page.Items)
+		return page, err
+	}
+
+	err := json.Unmarshal(body, &page)
+	return page, err
+}
+
+// GetEngineer fetches an engineer by ID. The boolean result is false when the
+// backend does not know the engineer.
+func (c *DevopsClient) GetEngineer(ctx context.Context, id string) (EngineerAPIModel, bool, error) {
+	engineer, _, found, err := c.fetchEngineer(ctx, id)
+	return engineer, found, err
+}
+
+// fetchEngineer is GetEngineer that also returns the response header carrying
+// the ETag of the engineer.
+func (c *DevopsClient) fetchEngineer(ctx context.Context, id string) (EngineerAPIModel, http.Header, bool, error) {
+	var engineer EngineerAPIModel
+
+	tflog.Debug(ctx, "Checking Engineer", map[string]interface{}{"engineerID": id})
+
+	httpResp, err := c.Get(c.URL("/engineers/id/") + url.PathEscape(id))
+	if err != nil {
+		return engineer, nil, false, err
+	}
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return engineer, nil, false, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return engineer, nil, false, nil
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return engineer, nil, false, fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.Unmarshal(bodyBytes, &engineer); err != nil {
+		return engineer, nil, false, err
+	}
+
+	return engineer, httpResp.Header, true, nil
+}
+
+// CreateEngineer creates an engineer and returns it as stored by the backend,
+// including the ID the backend assigned.
+func (c *DevopsClient) CreateEngineer(ctx context.Context, engineer EngineerAPIModel) (EngineerAPIModel, error) {
+	var created EngineerAPIModel
+
+	jsonData, err := json.Marshal(engineer)
+	if err != nil {
+		return created, err
+	}
+
+	tflog.Debug(ctx, "Creating Engineer", map[string]interface{}{"engineerData": string(jsonData)})
+
+	httpResp, err := c.Post(c.URL("/engineers"), "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return created, err
+	}
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return created, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
+		return created, fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.Unmarshal(bodyBytes, &created); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+// FindEngineersByEmail returns every engineer whose email matches email once
+// both are normalized.
+func (c *DevopsClient) FindEngineersByEmail(ctx context.Context, email string) ([]EngineerAPIModel, error) {
+	engineers, err := listAll[EngineerAPIModel](ctx, c.unbounded(), "/engineers")
+	if err != nil {
+		return nil, err
+	}
+
+	want := normalizeEmail(email)
+
+	var matches []EngineerAPIModel
+	for _, engineer := range engineers {
+		if normalizeEmail(engineer.Email) == want {
+			matches = append(matches, engineer)
+		}
+	}
+
+	return matches, nil
+}
+
+// UpdateEngineer replaces an engineer and returns it as stored by the backend,
+// along with the response header carrying its new ETag. A non-empty etag makes
+// the write conditional, and errPreconditionFailed is returned if the engineer
+// changed since.
+func (c *DevopsClient) UpdateEngineer(ctx context.Context, engineer EngineerAPIModel, etag string) (EngineerAPIModel, http.Header, error) {
+	var updated EngineerAPIModel
+
+	jsonData, err := json.Marshal(EngineerUpdateAPIModel{EngineerAPIModel: engineer, Archived: engineer.Archived})
+	if err != nil {
+		return updated, nil, err
+	}
+
+	tflog.Debug(ctx, "Updating Engineer", map[string]interface{}{"engineerData": string(jsonData)})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.URL("/engineers/")+url.PathEscape(engineer.Id), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return updated, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		return updated, nil, err
+	}
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return updated, nil, err
+	}
+
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		return updated, nil, errPreconditionFailed
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return updated, nil, fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.Unmarshal(bodyBytes, &updated); err != nil {
+		return updated, nil, err
+	}
+
+	return updated, httpResp.Header, nil
+}
+
+// TeamMembership is an ops or dev team and its engineers.
+type TeamMembership struct {
+	// Path is the collection the team lives in, "/op" or "/dev".
+	Path      string
+	Id        string
+	Name      string
+	Engineers []EngineerAPIModel
+
+	// ETag is the ETag of the team when it was fetched by ID with GetTeam,
+	// and empty for teams taken from a listing.
+	ETag string
+}
+
+// Kind names the kind of team in messages.
+func (t TeamMembership) Kind() string {
+	if t.Path == "/dev" {
+		return "dev team"
+	}
+
+	return "ops team"
+}
+
+// FindEngineerTeams returns every ops and dev team the engineer with the
+// given ID is a member of.
+func (c *DevopsClient) FindEngineerTeams(ctx context.Context, id string) ([]TeamMembership, error) {
+	apiOps, err := listAll[OpsAPIModel](ctx, c.unbounded(), "/op")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ops: %w", err)
+	}
+
+	apiDev, err := listAll[DevAPIModel](ctx, c.unbounded(), "/dev")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dev: %w", err)
+	}
+
+	var teams []TeamMembership
+	for _, op := range apiOps {
+		if hasEngineer(op.Engineers, id) {
+			teams = append(teams, TeamMembership{Path: "/op", Id: op.Id, Name: op.Name, Engineers: op.Engineers})
+		}
+	}
+	for _, dev := range apiDev {
+		if hasEngineer(dev.Engineers, id) {
+			teams = append(teams, TeamMembership{Path: "/dev", Id: dev.Id, Name: dev.Name, Engineers: dev.Engineers})
+		}
+	}
+
+	return teams, nil
+}
+
+// GetTeam fetches an ops or dev team by ID, along with its ETag.
+func (c *DevopsClient) GetTeam(ctx context.Context, teamPath, id string) (TeamMembership, error) {
+	// Ops and dev teams share the same JSON shape.
+	team, header, err := getTeam[OpsAPIModel](ctx, c, teamPath, id)
+	if err != nil {
+		return TeamMembership{}, err
+	}
+
+	return TeamMembership{Path: teamPath, Id: team.Id, Name: team.Name, Engineers: team.Engineers, ETag: header.Get("ETag")}, nil
+}
+
+// RemoveTeamEngineer replaces the engineers of a team with its current
+// engineers minus the one with the given ID. The write is conditional on the
+// ETag of the team, if it has one, and errPreconditionFailed is returned if
+// the team changed since it was fetched.
+func (c *DevopsClient) RemoveTeamEngineer(ctx context.Context, team TeamMembership, id string) error {
+	// Ops and dev teams share the same JSON shape.
+	body := OpsAPIModel{Name: team.Name, Id: team.Id, Engineers: []EngineerAPIModel{}}
+	for _, engineer := range team.Engineers {
+		if engineer.Id != id {
+			body.Engineers = append(body.Engineers, engineer)
+		}
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "Removing engineer from team", map[string]interface{}{"team": team.Id, "engineer": id})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.URL(team.Path+"/")+url.PathEscape(team.Id), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if team.ETag != "" {
+		req.Header.Set("If-Match", team.ETag)
+	}
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		return errPreconditionFailed
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent {
+		bodyBytes, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// capabilityTeamMembers is advertised by backends that can add and remove
+// single team members without replacing the whole team.
+const capabilityTeamMembers = "team-members"
+
+// capabilitiesAPIModel is returned by the capabilities endpoint.
+type capabilitiesAPIModel struct {
+	Features []string `json:"features"`
+}
+
+// capabilityCache holds the backend features, fetched at most once.
+type capabilityCache struct {
+	once     sync.Once
+	features map[string]bool
+}
+
+// HasCapability reports whether the backend advertises the given feature.
+// Backends without a capabilities endpoint advertise nothing.
+func (c *DevopsClient) HasCapability(ctx context.Context, feature string) bool {
+	if c.capabilities == nil {
+		return c.fetchCapabilities(ctx)[feature]
+	}
+
+	c.capabilities.once.Do(func() {
+		c.capabilities.features = c.fetchCapabilities(ctx)
+	})
+
+	return c.capabilities.features[feature]
+}
+
+// fetchCapabilities reads the backend features. Any failure is treated as no
+// features, so callers fall back to the endpoints every backend has.
+func (c *DevopsClient) fetchCapabilities(ctx context.Context) map[string]bool {
+	features := map[string]bool{}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL("/capabilities"), nil)
+	if err != nil {
+		return features
+	}
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read backend capabilities", map[string]interface{}{"error": err.Error()})
+		return features
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		tflog.Debug(ctx, "Backend does not advertise capabilities", map[string]interface{}{"status": httpResp.StatusCode})
+		return features
+	}
+
+	var capabilities capabilitiesAPIModel
+	if err := json.NewDecoder(httpResp.Body).Decode(&capabilities); err != nil {
+		tflog.Debug(ctx, "Unable to decode backend capabilities", map[string]interface{}{"error": err.Error()})
+		return features
+	}
+
+	for _, feature := range capabilities.Features {
+		features[feature] = true
+	}
+
+	tflog.Debug(ctx, "Backend capabilities", map[string]interface{}{"features": capabilities.Features})
+
+	return features
+}
+
+// AddTeamMember adds the engineer with the given ID to a team through the
+// member endpoint of backends with the team-members capability.
+func (c *DevopsClient) AddTeamMember(ctx context.Context, teamPath, teamID, engineerID string) error {
+	return c.teamMemberRequest(ctx, http.MethodPost, teamPath, teamID, engineerID)
+}
+
+// RemoveTeamMember removes the engineer with the given ID from a team through
+// the member endpoint of backends with the team-members capability. Removing
+// an engineer that is not on the team is not an error.
+func (c *DevopsClient) RemoveTeamMember(ctx context.Context, teamPath, teamID, engineerID string) error {
+	return c.teamMemberRequest(ctx, http.MethodDelete, teamPath, teamID, engineerID)
+}
+
+func (c *DevopsClient) teamMemberRequest(ctx context.Context, method, teamPath, teamID, engineerID string) error {
+	tflog.Debug(ctx, "Updating team member", map[string]interface{}{"method": method, "team": teamID, "engineer": engineerID})
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL(teamPath+"/")+url.PathEscape(teamID)+"/engineers/"+url.PathEscape(engineerID), nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	switch {
+	case httpResp.StatusCode == http.StatusOK, httpResp.StatusCode == http.StatusCreated, httpResp.StatusCode == http.StatusNoContent:
+		return nil
+	case httpResp.StatusCode == http.StatusNotFound && method == http.MethodDelete:
+		return nil
+	default:
+		bodyBytes, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+}
+
+// GetOps fetches an ops team by ID, along with the response header carrying
+// its ETag.
+func (c *DevopsClient) GetOps(ctx context.Context, id string) (OpsAPIModel, http.Header, error) {
+	return getTeam[OpsAPIModel](ctx, c, "/op", id)
+}
+
+// getTeam fetches the team with the given ID from the team collection at
+// teamPath, along with the response header carrying its ETag.
+func getTeam[T any](ctx context.Context, c *DevopsClient, teamPath, id string) (T, http.Header, error) {
+	var team T
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL(teamPath+"/id/")+url.PathEscape(id), nil)
+	if err != nil {
+		return team, nil, err
+	}
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		return team, nil, err
+	}
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return team, nil, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return team, nil, fmt.Errorf("unexpected status %d: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.Unmarshal(bodyBytes, &team); err != nil {
+		return team, nil, err
+	}
+
+	return team, httpResp.Header, nil
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// handlerErrors collects errors from httptest handlers. Handlers run outside
+// the test goroutine and must not call t.Fatal, so the errors are reported
+// when the test finishes.
+type handlerErrors struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// newHandlerErrors returns a collector that fails t with every recorded error
+// once the test finishes.
+func newHandlerErrors(t *testing.T) *handlerErrors {
+	h := &handlerErrors{}
+	t.Cleanup(func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		for _, err := range h.errs {
+			t.Errorf("server handler: %s", err)
+		}
+	})
+
+	return h
+}
+
+// fail records err and answers the request with a 400.
+func (h *handlerErrors) fail(w http.ResponseWriter, err error) {
+	h.mu.Lock()
+	h.errs = append(h.errs, err)
+	h.mu.Unlock()
+
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+func newPagingServer(t *testing.T, engineers []EngineerAPIModel) *httptest.Server {
+	errs := newHandlerErrors(t)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			errs.fail(w, fmt.Errorf("invalid limit: %w", err))
+			return
+		}
+
+		start := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+
+		end := start + limit
+		next := strconv.Itoa(end)
+		if end >= len(engineers) {
+			end = len(engineers)
+			next = ""
+		}
+
+		_ = json.NewEncoder(w).Encode(listPage[EngineerAPIModel]{
+			Items:      engineers[start:end],
+			NextCursor: next,
+		})
+	}))
+}
+
+func TestListAllFollowsCursor(t *testing.T) {
+	engineers := []EngineerAPIModel{
+		{Id: "H3ZTR", Name: "Ryan"},
+		{Id: "M3IGD", Name: "zach"},
+		{Id: "CTDSM", Name: "bob"},
+		{Id: "POE5O", Name: "grant"},
+		{Id: "MIGFP", Name: "wick"},
+	}
+
+	server := newPagingServer(t, engineers)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	client.PageSize = 2
+
+	got, err := listAll[EngineerAPIModel](context.Background(), client, "/engineers")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != len(engineers) {
+		t.Fatalf("expected %d engineers, got %d", len(engineers), len(got))
+	}
+
+	for i := range engineers {
+		if got[i].Id != engineers[i].Id {
+			t.Errorf("engineer %d: expected id %s, got %s", i, engineers[i].Id, got[i].Id)
+		}
+	}
+}
+
+func TestListAllMaxResults(t *testing.T) {
+	engineers := []EngineerAPIModel{
+		{Id: "H3ZTR"}, {Id: "M3IGD"}, {Id: "CTDSM"}, {Id: "POE5O"}, {Id: "MIGFP"},
+	}
+
+	server := newPagingServer(t, engineers)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	client.PageSize = 2
+	client.MaxResults = 3
+
+	got, err := listAll[EngineerAPIModel](context.Background(), client, "/engineers")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 engineers, got %d", len(got))
+	}
+}
+
+func TestCapResults(t *testing.T) {
+	engineers := []EngineerAPIModel{{Id: "H3ZTR"}, {Id: "M3IGD"}, {Id: "CTDSM"}}
+
+	if got := capResults(&DevopsClient{}, engineers); len(got) != 3 {
+		t.Errorf("expected no cap without max_results, got %d", len(got))
+	}
+
+	if got := capResults(&DevopsClient{MaxResults: 2}, engineers); len(got) != 2 || got[1].Id != "M3IGD" {
+		t.Errorf("expected the first 2 engineers, got %v", got)
+	}
+
+	if got := capResults(&DevopsClient{MaxResults: 5}, engineers); len(got) != 3 {
+		t.Errorf("expected all 3 engineers below the cap, got %d", len(got))
+	}
+}
+
+func TestUpdateEngineerSendsArchived(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(EngineerAPIModel{Id: "H3ZTR", Name: "Ryan"})
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	if _, _, err := client.UpdateEngineer(context.Background(), EngineerAPIModel{Id: "H3ZTR", Name: "Ryan"}, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if archived, ok := body["archived"]; !ok || archived != false {
+		t.Errorf("expected the update to send archived = false, got %v", body)
+	}
+
+	created, err := json.Marshal(EngineerAPIModel{Id: "H3ZTR", Name: "Ryan"})
+	if err != nil || strings.Contains(string(created), "archived") {
+		t.Errorf("expected archived to be omitted from other requests, got %s", created)
+	}
+}
+
+func TestListAllPlainArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":"MIGFP","name":"ops_ferrets","engineers":[]},{"id":"YBTQO","name":"ops_bengal","engineers":[]}]`))
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	got, err := listAll[OpsAPIModel](context.Background(), client, "/op")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 2 || got[1].Name != "ops_bengal" {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+}
+
+func TestHasCapabilityCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/capabilities" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		_ = json.NewEncoder(w).Encode(capabilitiesAPIModel{Features: []string{capabilityTeamMembers}})
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	if !client.HasCapability(context.Background(), capabilityTeamMembers) {
+		t.Error("expected the team-members capability")
+	}
+
+	if client.unbounded().HasCapability(context.Background(), "other") {
+		t.Error("expected an unadvertised capability to be missing")
+	}
+
+	if requests != 1 {
+		t.Errorf("expected capabilities to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestHasCapabilityWithoutEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	if client.HasCapability(context.Background(), capabilityTeamMembers) {
+		t.Error("expected no capabilities from a backend without the endpoint")
+	}
+}
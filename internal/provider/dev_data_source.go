@@ -7,10 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,7 +21,7 @@ func NewDevDataSource() datasource.DataSource {
 
 // DevDataSource defines the data source implementation.
 type DevDataSource struct {
-	client *http.Client
+	client *DevopsClient
 }
 
 // DevDataSourceModel describes the data source data model.
@@ -61,7 +57,8 @@ func (d *DevDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 										Computed: true,
 									},
 									"email": schema.StringAttribute{
-										Computed: true,
+										CustomType: EmailType{},
+										Computed:   true,
 									},
 								},
 							},
@@ -79,12 +76,12 @@ func (d *DevDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -94,33 +91,14 @@ func (d *DevDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 }
 
 func (d *DevDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var apiDev []DevAPIModel
 	var state DevDataSourceModel
 
-	// Make a call to your API to fetch the dev data
-	httpResp, err := d.client.Get("http://localhost:8080/dev")
+	// Fetch every page from the list endpoint
+	apiDev, err := listAll[DevAPIModel](ctx, d.client, "/dev")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dev, got error: %s", err))
 		return
 	}
-	defer httpResp.Body.Close()
-
-	// Read the response body
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-		return
-	}
-
-	// Print the response body
-	fmt.Println(string(bodyBytes))
-
-	// Decode the response body into the API model struct
-	err = json.Unmarshal(bodyBytes, &apiDev)
-	if err != nil {
-		resp.Diagnostics.AddError("Decode Error", fmt.Sprintf("Unable to decode response body, got error: %s", err))
-		return
-	}
 
 	// Convert API model to Terraform schema model and set in state
 	for _, apiDev := range apiDev {
@@ -133,7 +111,7 @@ func (d *DevDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 			devState.Engineers = append(devState.Engineers, EngineerTFModel{
 				Name:  types.StringValue(apiEngineer.Name),
 				Id:    types.StringValue(apiEngineer.Id),
-				Email: types.StringValue(apiEngineer.Email),
+				Email: NewEmailValue(apiEngineer.Email),
 			})
 		}
 
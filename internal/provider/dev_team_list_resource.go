@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &DevTeamListResource{}
+var _ list.ListResourceWithConfigure = &DevTeamListResource{}
+var _ list.ListResourceWithRawV6Schemas = &DevTeamListResource{}
+
+const devTeamTypeName = "_dev_team"
+
+func NewDevTeamListResource() list.ListResource {
+	return &DevTeamListResource{}
+}
+
+// DevTeamListResource defines the list resource implementation.
+type DevTeamListResource struct {
+	client *DevopsClient
+}
+
+// DevTeamListResourceModel describes the list resource config model.
+type DevTeamListResourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	EngineerId types.String `tfsdk:"engineer_id"`
+}
+
+func (r *DevTeamListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + devTeamTypeName
+}
+
+func (r *DevTeamListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists dev teams in the backend. Without filters every dev team is returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only list the dev team with this name.",
+				Optional:            true,
+			},
+			"engineer_id": schema.StringAttribute{
+				MarkdownDescription: "Only list dev teams that this engineer is a member of.",
+				Optional:            true,
+				Validators: []validator.String{
+					validID(),
+				},
+			},
+		},
+	}
+}
+
+// RawV6Schemas describes the dev team objects in the results. There is no
+// managed dev team resource, so the resource and identity schemas are given
+// here: a team with its name, id and engineers, identified like ops teams.
+func (r *DevTeamListResource) RawV6Schemas(ctx context.Context, req list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "name",
+					Type:     tftypes.String,
+					Required: true,
+				},
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "engineers",
+					Optional: true,
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSet,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "name",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "id",
+								Type:     tftypes.String,
+								Required: true,
+							},
+							{
+								Name:     "email",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp.ProtoV6IdentitySchema = &tfprotov6.ResourceIdentitySchema{
+		IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+			{
+				Name:              "endpoint",
+				Type:              tftypes.String,
+				OptionalForImport: true,
+				Description:       "Endpoint of the backend that holds the object. Defaults to the provider endpoint.",
+			},
+			{
+				Name:              "id",
+				Type:              tftypes.String,
+				RequiredForImport: true,
+				Description:       "Backend ID of the object.",
+			},
+		},
+	}
+}
+
+func (r *DevTeamListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DevopsClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DevTeamListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config DevTeamListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Filter every team, max_results and the query limit apply to the matches
+	apiDevs, err := listAll[DevAPIModel](ctx, r.client.unbounded(), "/dev")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read dev teams, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []DevAPIModel
+
+	for _, apiDev := range apiDevs {
+		if !config.Name.IsNull() && apiDev.Name != config.Name.ValueString() {
+			continue
+		}
+
+		if !config.EngineerId.IsNull() && !hasEngineer(apiDev.Engineers, config.EngineerId.ValueString()) {
+			continue
+		}
+
+		matches = append(matches, apiDev)
+	}
+
+	matches = limitListResults(req, capResults(r.client, matches))
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, apiDev := range matches {
+			result := req.NewListResult(ctx)
+			result.DisplayName = apiDev.Name
+
+			result.Diagnostics.Append(setResourceIdentity(ctx, result.Identity, r.client, apiDev.Id)...)
+
+			if req.IncludeResource {
+				data := DevTFModel{
+					Name: types.StringValue(apiDev.Name),
+					Id:   types.StringValue(apiDev.Id),
+				}
+
+				for _, apiEngineer := range apiDev.Engineers {
+					data.Engineers = append(data.Engineers, EngineerTFModel{
+						Name:  types.StringValue(apiEngineer.Name),
+						Id:    types.StringValue(apiEngineer.Id),
+						Email: NewEmailValue(apiEngineer.Email),
+					})
+				}
+
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -7,10 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,7 +21,7 @@ func NewDevopsDataSource() datasource.DataSource {
 
 // DevopsDataSource defines the data source implementation.
 type DevopsDataSource struct {
-	client *http.Client
+	client *DevopsClient
 }
 
 // DevopsDataSourceModel describes the data source data model.
@@ -68,7 +64,8 @@ func (d *DevopsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 													Computed: true,
 												},
 												"email": schema.StringAttribute{
-													Computed: true,
+													CustomType: EmailType{},
+													Computed:   true,
 												},
 											},
 										},
@@ -97,7 +94,8 @@ func (d *DevopsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 													Computed: true,
 												},
 												"email": schema.StringAttribute{
-													Computed: true,
+													CustomType: EmailType{},
+													Computed:   true,
 												},
 											},
 										},
@@ -118,12 +116,12 @@ func (d *DevopsDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -133,30 +131,14 @@ func (d *DevopsDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 func (d *DevopsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var apiDevops []DevopsAPIModel
 	var state DevopsDataSourceModel
 
-	// Make a call to your API to fetch the devops data
-	httpResp, err := d.client.Get("http://localhost:8080/devops")
+	// Fetch every page from the list endpoint
+	apiDevops, err := listAll[DevopsAPIModel](ctx, d.client, "/devops")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read devops, got error: %s", err))
 		return
 	}
-	defer httpResp.Body.Close()
-
-	// Read the response body
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-		return
-	}
-
-	// Decode the response body into the API model struct
-	err = json.Unmarshal(bodyBytes, &apiDevops)
-	if err != nil {
-		resp.Diagnostics.AddError("Decode Error", fmt.Sprintf("Unable to decode response body, got error: %s", err))
-		return
-	}
 
 	// Convert API model to Terraform schema model and set in state
 	for _, apiDevopsItem := range apiDevops {
@@ -211,7 +193,7 @@ func (d *DevopsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 // 		tfItem := EngineerTFModel{
 // 			Name:  types.StringValue(apiItem.Name),
 // 			Id:    types.StringValue(apiItem.Id),
-// 			Email: types.StringValue(apiItem.Email),
+// 			Email: NewEmailValue(apiItem.Email),
 // 		}
 // 		tfModel = append(tfModel, tfItem)
 // 	}
@@ -0,0 +1,323 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dryRunCollections lists the backend collections writes are simulated for.
+var dryRunCollections = map[string]bool{
+	"engineers": true,
+	"op":        true,
+	"dev":       true,
+}
+
+// dryRunTransport simulates every write instead of sending it. Created and
+// updated objects are kept in memory and served back on later reads, merged
+// into the backend lists, so resources planned together can refer to each
+// other. Reads of anything else go to the backend unchanged.
+type dryRunTransport struct {
+	base http.RoundTripper
+
+	// prefix is the path of the provider endpoint, stripped before routing.
+	prefix string
+
+	mu sync.Mutex
+
+	// objects holds the simulated objects by collection and ID. A nil object
+	// is one deleted by the simulation.
+	objects map[string]map[string]map[string]interface{}
+
+	// created lists the IDs of simulated new objects by collection, in the
+	// order they were created.
+	created map[string][]string
+}
+
+// newDryRunTransport returns a dry run transport for the given endpoint.
+func newDryRunTransport(base http.RoundTripper, endpoint string) *dryRunTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	prefix := ""
+	if endpointURL, err := url.Parse(endpoint); err == nil {
+		prefix = strings.TrimRight(endpointURL.Path, "/")
+	}
+
+	return &dryRunTransport{
+		base:    base,
+		prefix:  prefix,
+		objects: map[string]map[string]map[string]interface{}{},
+		created: map[string][]string{},
+	}
+}
+
+// dryRunHTTPClient returns a copy of httpClient that simulates writes against
+// endpoint.
+func dryRunHTTPClient(httpClient *http.Client, endpoint string) *http.Client {
+	clone := *httpClient
+	clone.Transport = newDryRunTransport(clone.Transport, endpoint)
+
+	return &clone
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, t.prefix), "/"), "/")
+	collection := segments[0]
+
+	// Member endpoints are not simulated, so advertise none and let team
+	// updates use full PUTs.
+	if req.Method == http.MethodGet && len(segments) == 1 && collection == "capabilities" {
+		return dryRunResponse(req, http.StatusOK, capabilitiesAPIModel{Features: []string{}})
+	}
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if !dryRunCollections[collection] {
+			return t.base.RoundTrip(req)
+		}
+
+		switch {
+		case len(segments) == 1:
+			return t.list(req, collection)
+		case len(segments) == 3 && segments[1] == "id":
+			return t.get(req, collection, segments[2])
+		default:
+			return t.base.RoundTrip(req)
+		}
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("[INFO] dry_run: would send %s %s: %s", req.Method, req.URL.Redacted(), string(body))
+
+	if !dryRunCollections[collection] {
+		return nil, fmt.Errorf("dry_run: %s %s cannot be simulated", req.Method, req.URL.Path)
+	}
+
+	switch {
+	case req.Method == http.MethodPost && len(segments) == 1:
+		return t.create(req, collection, body)
+	case req.Method == http.MethodPut && len(segments) == 2:
+		return t.update(req, collection, segments[1], body)
+	case req.Method == http.MethodDelete && len(segments) == 2:
+		return t.delete(req, collection, segments[1])
+	default:
+		return nil, fmt.Errorf("dry_run: %s %s cannot be simulated", req.Method, req.URL.Path)
+	}
+}
+
+// create simulates a POST to a collection, assigning a deterministic ID.
+func (t *dryRunTransport) create(req *http.Request, collection string, body []byte) (*http.Response, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return dryRunResponse(req, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.newID(collection, object)
+	object["id"] = id
+
+	t.store(collection, id, object)
+	t.created[collection] = append(t.created[collection], id)
+
+	log.Printf("[INFO] dry_run: simulated %s/%s", collection, id)
+
+	return dryRunResponse(req, http.StatusCreated, object)
+}
+
+// update simulates a PUT of an object.
+func (t *dryRunTransport) update(req *http.Request, collection, id string, body []byte) (*http.Response, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return dryRunResponse(req, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	object["id"] = id
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.store(collection, id, object)
+
+	return dryRunResponse(req, http.StatusOK, object)
+}
+
+// delete simulates a DELETE of an object.
+func (t *dryRunTransport) delete(req *http.Request, collection, id string) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.store(collection, id, nil)
+
+	return dryRunResponse(req, http.StatusOK, map[string]string{"id": id})
+}
+
+// get serves a simulated object, or reads it from the backend if the
+// simulation has not touched it.
+func (t *dryRunTransport) get(req *http.Request, collection, id string) (*http.Response, error) {
+	t.mu.Lock()
+	object, ok := t.objects[collection][id]
+	t.mu.Unlock()
+
+	switch {
+	case !ok:
+		return t.base.RoundTrip(req)
+	case object == nil:
+		return dryRunResponse(req, http.StatusNotFound, map[string]string{"error": "not found"})
+	default:
+		return dryRunResponse(req, http.StatusOK, object)
+	}
+}
+
+// list reads a page of a collection from the backend and applies the
+// simulation to it: simulated updates replace backend items, simulated
+// deletes are dropped, and simulated new objects are added to the last page.
+func (t *dryRunTransport) list(req *http.Request, collection string) (*http.Response, error) {
+	t.mu.Lock()
+	touched := len(t.objects[collection]) > 0
+	t.mu.Unlock()
+
+	if !touched {
+		return t.base.RoundTrip(req)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return dryRunRawResponse(req, resp.StatusCode, body), nil
+	}
+
+	// Accept the same page shapes as decodeListPage.
+	var page map[string]interface{}
+	var items []map[string]interface{}
+
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		err = json.Unmarshal(body, &items)
+	} else if err = json.Unmarshal(body, &page); err == nil {
+		var pageItems struct {
+			Items []map[string]interface{} `json:"items"`
+		}
+		err = json.Unmarshal(body, &pageItems)
+		items = pageItems.Items
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	merged := []map[string]interface{}{}
+	seen := map[string]bool{}
+
+	for _, item := range items {
+		id, _ := item["id"].(string)
+
+		if object, ok := t.objects[collection][id]; ok {
+			seen[id] = true
+			if object == nil {
+				continue
+			}
+			item = object
+		}
+
+		merged = append(merged, item)
+	}
+
+	if cursor, _ := page["next_cursor"].(string); cursor == "" {
+		for _, id := range t.created[collection] {
+			if object := t.objects[collection][id]; object != nil && !seen[id] {
+				merged = append(merged, object)
+			}
+		}
+	}
+
+	if page == nil {
+		return dryRunResponse(req, http.StatusOK, merged)
+	}
+
+	page["items"] = merged
+
+	return dryRunResponse(req, http.StatusOK, page)
+}
+
+// newID returns an unused ID in the backend format derived from the object,
+// so a dry run of the same configuration assigns the same IDs.
+func (t *dryRunTransport) newID(collection string, object map[string]interface{}) string {
+	key, _ := object["email"].(string)
+	if key == "" {
+		key, _ = object["name"].(string)
+	}
+
+	seed := "dry-run/" + collection + "/" + strings.ToLower(strings.TrimSpace(key))
+
+	for n := 0; ; n++ {
+		id := idFromSeed(seed + "#" + strconv.Itoa(n))
+		if _, taken := t.objects[collection][id]; !taken {
+			return id
+		}
+	}
+}
+
+// store records a simulated object, or a deletion when object is nil.
+func (t *dryRunTransport) store(collection, id string, object map[string]interface{}) {
+	if t.objects[collection] == nil {
+		t.objects[collection] = map[string]map[string]interface{}{}
+	}
+
+	t.objects[collection][id] = object
+}
+
+// dryRunResponse returns a simulated JSON response.
+func dryRunResponse(req *http.Request, status int, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return dryRunRawResponse(req, status, data), nil
+}
+
+func dryRunRawResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	var writes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodGet:
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		case r.URL.Path == "/api/engineers":
+			_ = json.NewEncoder(w).Encode(listPage[EngineerAPIModel]{Items: []EngineerAPIModel{
+				{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+				{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+			}})
+		case r.URL.Path == "/api/engineers/id/H3ZTR":
+			_ = json.NewEncoder(w).Encode(EngineerAPIModel{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewDevopsClient(dryRunHTTPClient(server.Client(), server.URL+"/api"), server.URL+"/api")
+
+	created, err := client.CreateEngineer(ctx, EngineerAPIModel{Name: "wick", Email: "wick@google.com"})
+	if err != nil {
+		t.Fatalf("unexpected error creating engineer: %s", err)
+	}
+
+	if err := validateID(created.Id); err != nil {
+		t.Errorf("expected a simulated ID in the backend format: %s", err)
+	}
+
+	// The same configuration gets the same IDs
+	again, err := NewDevopsClient(dryRunHTTPClient(server.Client(), server.URL+"/api"), server.URL+"/api").
+		CreateEngineer(ctx, EngineerAPIModel{Name: "wick", Email: "wick@google.com"})
+	if err != nil || again.Id != created.Id {
+		t.Errorf("expected the ID %s to be deterministic, got %s, %v", created.Id, again.Id, err)
+	}
+
+	if engineer, found, err := client.GetEngineer(ctx, created.Id); err != nil || !found || engineer.Email != "wick@google.com" {
+		t.Errorf("expected the simulated engineer to be readable, got %+v, %t, %v", engineer, found, err)
+	}
+
+	if _, _, err := client.UpdateEngineer(ctx, EngineerAPIModel{Id: "H3ZTR", Name: "Ryan Ferret", Email: "ryan@ferrets.com"}, ""); err != nil {
+		t.Fatalf("unexpected error updating engineer: %s", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, client.URL("/engineers/POE5O"), nil)
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("unexpected error deleting engineer: %s", err)
+	}
+
+	engineers, err := listAll[EngineerAPIModel](ctx, client, "/engineers")
+	if err != nil {
+		t.Fatalf("unexpected error listing engineers: %s", err)
+	}
+
+	if len(engineers) != 2 || engineers[0].Name != "Ryan Ferret" || engineers[1].Id != created.Id {
+		t.Errorf("expected the list to reflect the simulated writes, got %+v", engineers)
+	}
+
+	if _, found, _ := client.GetEngineer(ctx, "POE5O"); found {
+		t.Error("expected the simulated delete to hide the engineer")
+	}
+
+	if client.HasCapability(ctx, capabilityTeamMembers) {
+		t.Error("expected no member endpoints in a dry run")
+	}
+
+	if len(writes) != 0 {
+		t.Errorf("expected no writes to reach the backend, got %v", writes)
+	}
+}
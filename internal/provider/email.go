@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxEmailLength     = 254
+	maxLocalPartLength = 64
+	maxLabelLength     = 63
+)
+
+// normalizeEmail returns the canonical form the provider uses when comparing
+// emails: surrounding whitespace is trimmed, the address is lowercased and a
+// "+tag" suffix on the local part is removed. Two emails refer to the same
+// engineer when their normalized forms match.
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+
+	return local + "@" + domain
+}
+
+// validateEmail checks email against an RFC 5322-lite subset: a dot-atom local
+// part, a DNS host name with an alphabetic top level domain and the standard
+// length limits. Surrounding whitespace is ignored.
+func validateEmail(email string) error {
+	email = strings.TrimSpace(email)
+
+	if email == "" {
+		return errors.New("email is empty")
+	}
+
+	if len(email) > maxEmailLength {
+		return fmt.Errorf("email is longer than %d characters", maxEmailLength)
+	}
+
+	if strings.Count(email, "@") != 1 {
+		return errors.New("email must contain exactly one \"@\"")
+	}
+
+	at := strings.Index(email, "@")
+	local, domain := email[:at], email[at+1:]
+
+	if err := validateLocalPart(local); err != nil {
+		return err
+	}
+
+	return validateDomain(domain)
+}
+
+func validateLocalPart(local string) error {
+	if local == "" {
+		return errors.New("local part is empty")
+	}
+
+	if len(local) > maxLocalPartLength {
+		return fmt.Errorf("local part is longer than %d characters", maxLocalPartLength)
+	}
+
+	if strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return errors.New("local part must not start or end with a dot or contain consecutive dots")
+	}
+
+	for _, r := range local {
+		if !isAtext(r) && r != '.' {
+			return fmt.Errorf("local part contains invalid character %q", r)
+		}
+	}
+
+	return nil
+}
+
+func validateDomain(domain string) error {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("domain %q must contain at least one dot", domain)
+	}
+
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("domain %q contains an empty label", domain)
+		}
+
+		if len(label) > maxLabelLength {
+			return fmt.Errorf("domain label %q is longer than %d characters", label, maxLabelLength)
+		}
+
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("domain label %q must not start or end with a hyphen", label)
+		}
+
+		for _, r := range label {
+			if !isAlphaNumeric(r) && r != '-' {
+				return fmt.Errorf("domain contains invalid character %q", r)
+			}
+		}
+	}
+
+	tld := labels[len(labels)-1]
+	if len(tld) < 2 {
+		return fmt.Errorf("top level domain %q must be at least two characters", tld)
+	}
+
+	for _, r := range tld {
+		if !isAlpha(r) {
+			return fmt.Errorf("top level domain %q must only contain letters", tld)
+		}
+	}
+
+	return nil
+}
+
+func isAlpha(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isAlphaNumeric(r rune) bool {
+	return isAlpha(r) || (r >= '0' && r <= '9')
+}
+
+// isAtext reports whether r is an RFC 5322 atext character.
+func isAtext(r rune) bool {
+	return isAlphaNumeric(r) || strings.ContainsRune("!#$%&'*+/=?^_`{|}~-", r)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "sync"
+
+// emailRegistry records the engineer emails used by the engineer resources
+// of a configuration so that the same email used by several of them can be
+// reported. A registry lives as long as the provider instance, which
+// Terraform creates afresh for each operation.
+//
+// Each use is keyed by the backend ID of the engineer the resource manages,
+// so planning or creating the same resource again does not count twice, and
+// two resources with identical configurations still create two engineers
+// with two IDs.
+type emailRegistry struct {
+	mu       sync.Mutex
+	declared map[string]map[string]bool
+}
+
+func newEmailRegistry() *emailRegistry {
+	return &emailRegistry{declared: map[string]map[string]bool{}}
+}
+
+// declare records that the engineer with the given ID uses email, and
+// returns how many distinct engineers use it so far.
+func (r *emailRegistry) declare(email, id string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := normalizeEmail(email)
+	if r.declared[key] == nil {
+		r.declared[key] = map[string]bool{}
+	}
+	r.declared[key][id] = true
+
+	return len(r.declared[key])
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailRegistry(t *testing.T) {
+	registry := newEmailRegistry()
+
+	if count := registry.declare("grant@google.com", "POE5O"); count != 1 {
+		t.Errorf("expected first declaration to count 1, got %d", count)
+	}
+
+	if count := registry.declare("grant@google.com", "POE5O"); count != 1 {
+		t.Errorf("expected declaring the same engineer again to count 1, got %d", count)
+	}
+
+	if count := registry.declare("ben@google.com", "BEN01"); count != 1 {
+		t.Errorf("expected a different email to count 1, got %d", count)
+	}
+
+	if count := registry.declare(" Grant+ops@Google.com", "GRNT2"); count != 2 {
+		t.Errorf("expected an equivalent email to count 2, got %d", count)
+	}
+}
+
+func TestEngineerResourceDuplicateEmail(t *testing.T) {
+	errs := newHandlerErrors(t)
+	var created int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/engineers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var engineer EngineerAPIModel
+		if err := json.NewDecoder(r.Body).Decode(&engineer); err != nil {
+			errs.fail(w, fmt.Errorf("invalid engineer: %w", err))
+			return
+		}
+		created++
+		engineer.Id = fmt.Sprintf("ENG%02d", created)
+		_ = json.NewEncoder(w).Encode(engineer)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p := providerserver.NewProtocol6(New("test")())()
+
+	schemaResp, err := p.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemaResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected schema error: %v, %v", err, schemaResp.Diagnostics)
+	}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := testNullAttributes(providerType)
+	providerConfig["endpoint"] = tftypes.NewValue(tftypes.String, server.URL)
+
+	configureResp, err := p.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, providerType, providerConfig),
+	})
+	if err != nil || len(configureResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected configure error: %v, %v", err, configureResp.Diagnostics)
+	}
+
+	// Two engineer resources with identical configurations
+	engineerType := schemaResp.ResourceSchemas["devops-bootcamp_engineer"].ValueType().(tftypes.Object)
+	config := testNullAttributes(engineerType)
+	config["name"] = tftypes.NewValue(tftypes.String, "grant")
+	config["email"] = tftypes.NewValue(tftypes.String, "grant@google.com")
+
+	planned := testNullAttributes(engineerType)
+	for name, value := range config {
+		planned[name] = value
+	}
+	planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	var warnings []int
+	for i := 0; i < 2; i++ {
+		applyResp, err := p.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName:     "devops-bootcamp_engineer",
+			PriorState:   testDynamicValue(t, engineerType, nil),
+			PlannedState: testDynamicValue(t, engineerType, planned),
+			Config:       testDynamicValue(t, engineerType, config),
+		})
+		if err != nil {
+			t.Fatalf("unexpected apply error: %s", err)
+		}
+
+		count := 0
+		for _, diagnostic := range applyResp.Diagnostics {
+			if diagnostic.Severity != tfprotov6.DiagnosticSeverityWarning || diagnostic.Summary != "Duplicate Engineer Email" {
+				t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+			count++
+		}
+		warnings = append(warnings, count)
+	}
+
+	if created != 2 || warnings[0] != 0 || warnings[1] != 1 {
+		t.Errorf("expected the second of 2 creates to warn, got %d creates and warnings %v", created, warnings)
+	}
+}
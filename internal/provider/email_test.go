@@ -0,0 +1,61 @@
+package provider
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"grant@google.com":         "grant@google.com",
+		"  Grant@Google.com ":      "grant@google.com",
+		"grant+ops@google.com":     "grant@google.com",
+		"GRANT+Ops+x@Google.COM":   "grant@google.com",
+		"+grant@google.com":        "+grant@google.com",
+		"first.last@sub.google.io": "first.last@sub.google.io",
+	}
+
+	for input, expected := range tests {
+		if got := normalizeEmail(input); got != expected {
+			t.Errorf("normalizeEmail(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	valid := []string{
+		"grant@google.com",
+		" grant@google.com ",
+		"first.last+tag@sub.google.io",
+		"o'brien@example.co",
+		"a-b_c@my-host.example.com",
+	}
+
+	for _, email := range valid {
+		if err := validateEmail(email); err != nil {
+			t.Errorf("validateEmail(%q): unexpected error: %s", email, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"grant",
+		"grant@",
+		"@google.com",
+		"grant@@google.com",
+		"gr@nt@google.com",
+		".grant@google.com",
+		"grant.@google.com",
+		"gr..ant@google.com",
+		"gr ant@google.com",
+		"grant@google",
+		"grant@google.c",
+		"grant@google.c0m",
+		"grant@-google.com",
+		"grant@google..com",
+		"grant@goo_gle.com",
+	}
+
+	for _, email := range invalid {
+		if err := validateEmail(email); err == nil {
+			t.Errorf("validateEmail(%q): expected an error", email)
+		}
+	}
+}
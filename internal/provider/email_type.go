@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the email type and value satisfy the framework interfaces.
+var _ basetypes.StringTypable = EmailType{}
+var _ basetypes.StringValuableWithSemanticEquals = EmailValue{}
+
+// EmailType is the attribute type for engineer emails.
+type EmailType struct {
+	basetypes.StringType
+}
+
+func (t EmailType) String() string {
+	return "EmailType"
+}
+
+func (t EmailType) ValueType(ctx context.Context) attr.Value {
+	return EmailValue{}
+}
+
+func (t EmailType) Equal(o attr.Type) bool {
+	other, ok := o.(EmailType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t EmailType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return EmailValue{StringValue: in}, nil
+}
+
+func (t EmailType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// EmailValue is an engineer email. Two emails are semantically equal when they
+// have the same normalizeEmail form, that is when they only differ in case,
+// surrounding whitespace or a "+tag" suffix on the local part, so a backend
+// that lowercases emails or drops the tag does not produce a diff.
+type EmailValue struct {
+	basetypes.StringValue
+}
+
+// NewEmailValue returns a known email value.
+func NewEmailValue(value string) EmailValue {
+	return EmailValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewEmailNull returns a null email value.
+func NewEmailNull() EmailValue {
+	return EmailValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewEmailUnknown returns an unknown email value.
+func NewEmailUnknown() EmailValue {
+	return EmailValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v EmailValue) Type(ctx context.Context) attr.Type {
+	return EmailType{}
+}
+
+func (v EmailValue) Equal(o attr.Value) bool {
+	other, ok := o.(EmailValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v EmailValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(EmailValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return normalizeEmail(v.ValueString()) == normalizeEmail(newValue.ValueString()), diags
+}
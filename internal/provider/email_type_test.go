@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailValueSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		prior    string
+		new      string
+		expected bool
+	}{
+		"identical":  {prior: "grant@google.com", new: "grant@google.com", expected: true},
+		"case":       {prior: "Grant@Google.com", new: "grant@google.com", expected: true},
+		"whitespace": {prior: " grant@google.com ", new: "grant@google.com", expected: true},
+		"different":  {prior: "grant@google.com", new: "ben@google.com"},
+		"plus tag":   {prior: "grant+ops@google.com", new: "grant@google.com", expected: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := NewEmailValue(tc.prior).StringSemanticEquals(context.Background(), NewEmailValue(tc.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEmailValueState(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewEngineerResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	in := newEngineerResourceModel(EngineerTFModel{
+		Name:  types.StringValue("grant"),
+		Id:    types.StringValue("POE5O"),
+		Email: NewEmailValue("Grant@Google.com"),
+	})
+
+	if diags := state.Set(ctx, &in); diags.HasError() {
+		t.Fatalf("unexpected diagnostics setting state: %v", diags)
+	}
+
+	var out EngineerResourceModel
+	if diags := state.Get(ctx, &out); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading state: %v", diags)
+	}
+
+	if !out.Email.Equal(in.Email) {
+		t.Errorf("expected %s, got %s", in.Email, out.Email)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptExisting reports whether Create should adopt an existing engineer. The
+// resource setting takes precedence over the provider default.
+func (r *EngineerResource) adoptExisting(data EngineerResourceModel) bool {
+	if !data.AdoptExisting.IsNull() {
+		return data.AdoptExisting.ValueBool()
+	}
+
+	return r.client != nil && r.client.AdoptExistingEngineers
+}
+
+// adoptEngineer looks up the engineer with the planned email, restores it if
+// it is archived and renames it to the planned name if needed. Only archived
+// engineers are considered when archivedOnly is set. It returns false when no
+// engineer has the email, in which case a new engineer should be created.
+// The returned header carries the ETag of the adopted engineer. Failures are
+// reported in the response diagnostics.
+func (r *EngineerResource) adoptEngineer(ctx context.Context, data EngineerResourceModel, archivedOnly bool, resp *resource.CreateResponse) (EngineerAPIModel, http.Header, bool) {
+	found, err := r.client.FindEngineersByEmail(ctx, data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up engineer by email, got error: %s", err))
+		return EngineerAPIModel{}, nil, true
+	}
+
+	matches := found
+	if archivedOnly {
+		matches = nil
+		for _, engineer := range found {
+			if engineer.Archived {
+				matches = append(matches, engineer)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return EngineerAPIModel{}, nil, false
+	case 1:
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.Id)
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Ambiguous Engineer Email",
+			fmt.Sprintf("%d engineers have email %s (IDs %s), so none of them can be adopted or restored. "+
+				"Import the one to manage by ID instead.", len(matches), data.Email.ValueString(), strings.Join(ids, ", ")),
+		)
+		return EngineerAPIModel{}, nil, true
+	}
+
+	// Read the engineer itself for its ETag, so that a rename or restore does
+	// not overwrite a change made since the lookup
+	engineer, header, exists, err := r.client.fetchEngineer(ctx, matches[0].Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineer %s, got error: %s", matches[0].Id, err))
+		return EngineerAPIModel{}, nil, true
+	}
+
+	if !exists {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Engineer %s was removed while it was being adopted.", matches[0].Id))
+		return EngineerAPIModel{}, nil, true
+	}
+
+	tflog.Debug(ctx, "Adopting existing engineer", map[string]interface{}{"id": engineer.Id, "email": engineer.Email, "archived": engineer.Archived})
+
+	if engineer.Name == data.Name.ValueString() && !engineer.Archived {
+		return engineer, header, true
+	}
+
+	engineer.Name = data.Name.ValueString()
+	engineer.Archived = false
+
+	updated, header, err := r.client.UpdateEngineer(ctx, engineer, header.Get("ETag"))
+	if errors.Is(err, errPreconditionFailed) {
+		resp.Diagnostics.Append(preconditionFailed("engineer", engineer.Id))
+		return EngineerAPIModel{}, nil, true
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update adopted engineer %s, got error: %s", engineer.Id, err))
+		return EngineerAPIModel{}, nil, true
+	}
+
+	return updated, header, true
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newAdoptServer serves the engineers list and each engineer with the ETag
+// "<id>-1", records renames of H3ZTR in updated and records the engineers
+// created with POST /engineers in created. Renames must send If-Match with the
+// current ETag, and their responses carry the ETag "H3ZTR-2".
+func newAdoptServer(t *testing.T, engineers []EngineerAPIModel, updated, created *[]EngineerAPIModel) *httptest.Server {
+	errs := newHandlerErrors(t)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/engineers":
+			_ = json.NewEncoder(w).Encode(engineers)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/engineers/id/"):
+			id := strings.TrimPrefix(r.URL.Path, "/engineers/id/")
+			for _, engineer := range engineers {
+				if engineer.Id == id {
+					w.Header().Set("ETag", `"`+id+`-1"`)
+					_ = json.NewEncoder(w).Encode(engineer)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut && r.URL.Path == "/engineers/H3ZTR":
+			if r.Header.Get("If-Match") != `"H3ZTR-1"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+
+			var engineer EngineerAPIModel
+			if err := json.NewDecoder(r.Body).Decode(&engineer); err != nil {
+				errs.fail(w, fmt.Errorf("invalid engineer: %w", err))
+				return
+			}
+			*updated = append(*updated, engineer)
+			w.Header().Set("ETag", `"H3ZTR-2"`)
+			_ = json.NewEncoder(w).Encode(engineer)
+		case r.Method == http.MethodPost && r.URL.Path == "/engineers":
+			var engineer EngineerAPIModel
+			if err := json.NewDecoder(r.Body).Decode(&engineer); err != nil {
+				errs.fail(w, fmt.Errorf("invalid engineer: %w", err))
+				return
+			}
+			engineer.Id = idFromSeed(engineer.Email)
+			*created = append(*created, engineer)
+			_ = json.NewEncoder(w).Encode(engineer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func testAdoptModel(name, email string) EngineerResourceModel {
+	return EngineerResourceModel{
+		Name:          types.StringValue(name),
+		Id:            types.StringUnknown(),
+		Email:         NewEmailValue(email),
+		AdoptExisting: types.BoolValue(true),
+	}
+}
+
+func TestEngineerResourceAdoptExistingDefault(t *testing.T) {
+	r := &EngineerResource{client: &DevopsClient{AdoptExistingEngineers: true}}
+
+	if !r.adoptExisting(EngineerResourceModel{AdoptExisting: types.BoolNull()}) {
+		t.Error("expected the provider default to apply when adopt_existing is unset")
+	}
+
+	if r.adoptExisting(EngineerResourceModel{AdoptExisting: types.BoolValue(false)}) {
+		t.Error("expected adopt_existing = false to override the provider default")
+	}
+}
+
+func TestEngineerResourceAdoptEngineer(t *testing.T) {
+	var updated, created []EngineerAPIModel
+	server := newAdoptServer(t, []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}, &updated, &created)
+	defer server.Close()
+
+	r := &EngineerResource{client: NewDevopsClient(server.Client(), server.URL)}
+
+	resp := &resource.CreateResponse{}
+	engineer, header, ok := r.adoptEngineer(context.Background(), testAdoptModel("Ryan", "Ryan@Ferrets.com"), false, resp)
+
+	if !ok || resp.Diagnostics.HasError() || engineer.Id != "H3ZTR" {
+		t.Fatalf("expected H3ZTR to be adopted, got %+v, %v", engineer, resp.Diagnostics)
+	}
+
+	if etag := header.Get("ETag"); etag != `"H3ZTR-1"` {
+		t.Errorf("expected the ETag of the adopted engineer, got %q", etag)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("expected no rename when the name matches, got %v", updated)
+	}
+
+	engineer, header, ok = r.adoptEngineer(context.Background(), testAdoptModel("Ryan Ferret", "ryan@ferrets.com"), false, resp)
+
+	if !ok || resp.Diagnostics.HasError() || engineer.Name != "Ryan Ferret" {
+		t.Fatalf("expected the adopted engineer to be renamed, got %+v, %v", engineer, resp.Diagnostics)
+	}
+
+	if etag := header.Get("ETag"); etag != `"H3ZTR-2"` {
+		t.Errorf("expected the ETag of the renamed engineer, got %q", etag)
+	}
+
+	if len(updated) != 1 || updated[0].Id != "H3ZTR" || updated[0].Email != "ryan@ferrets.com" {
+		t.Errorf("expected one rename of H3ZTR, got %v", updated)
+	}
+
+	_, _, ok = r.adoptEngineer(context.Background(), testAdoptModel("grant", "grant@google.com"), false, resp)
+
+	if ok || resp.Diagnostics.HasError() {
+		t.Errorf("expected no engineer to adopt for a new email, got %v", resp.Diagnostics)
+	}
+
+	engineer, _, ok = r.adoptEngineer(context.Background(), testAdoptModel("Ryan", "ryan+ops@ferrets.com"), false, resp)
+
+	if !ok || resp.Diagnostics.HasError() || engineer.Id != "H3ZTR" {
+		t.Errorf("expected a +tag address to adopt the untagged engineer, got %+v, %v", engineer, resp.Diagnostics)
+	}
+
+	if len(created) != 0 {
+		t.Errorf("expected adoption not to create engineers, got %v", created)
+	}
+}
+
+func TestEngineerResourceCreateWithoutMatch(t *testing.T) {
+	var updated, created []EngineerAPIModel
+	server := newAdoptServer(t, []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}, &updated, &created)
+	defer server.Close()
+
+	ctx := context.Background()
+	p := providerserver.NewProtocol6(New("test")())()
+
+	schemaResp, err := p.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemaResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected schema error: %v, %v", err, schemaResp.Diagnostics)
+	}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := testNullAttributes(providerType)
+	providerConfig["endpoint"] = tftypes.NewValue(tftypes.String, server.URL)
+	providerConfig["adopt_existing_engineers"] = tftypes.NewValue(tftypes.Bool, true)
+
+	configureResp, err := p.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, providerType, providerConfig),
+	})
+	if err != nil || len(configureResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected configure error: %v, %v", err, configureResp.Diagnostics)
+	}
+
+	engineerType := schemaResp.ResourceSchemas["devops-bootcamp_engineer"].ValueType().(tftypes.Object)
+	config := testNullAttributes(engineerType)
+	config["name"] = tftypes.NewValue(tftypes.String, "grant")
+	config["email"] = tftypes.NewValue(tftypes.String, "grant@google.com")
+
+	planned := testNullAttributes(engineerType)
+	for name, value := range config {
+		planned[name] = value
+	}
+	planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	applyResp, err := p.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "devops-bootcamp_engineer",
+		PriorState:   testDynamicValue(t, engineerType, nil),
+		PlannedState: testDynamicValue(t, engineerType, planned),
+		Config:       testDynamicValue(t, engineerType, config),
+	})
+	if err != nil || len(applyResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected apply error: %v, %v", err, applyResp.Diagnostics)
+	}
+
+	if len(created) != 1 || created[0].Email != "grant@google.com" {
+		t.Fatalf("expected one POST for grant, got %v", created)
+	}
+
+	state, err := applyResp.NewState.Unmarshal(engineerType)
+	if err != nil {
+		t.Fatalf("unexpected error reading state: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("unexpected error reading state: %s", err)
+	}
+
+	var id string
+	if err := attributes["id"].As(&id); err != nil || id != created[0].Id {
+		t.Errorf("expected state id %q, got %q (%v)", created[0].Id, id, err)
+	}
+}
+
+// testNullAttributes returns a null value for every attribute of objectType.
+func testNullAttributes(objectType tftypes.Object) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return values
+}
+
+// testDynamicValue encodes values as an object of objectType, or a null object
+// when values is nil.
+func testDynamicValue(t *testing.T, objectType tftypes.Object, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	var value tftypes.Value
+	if values == nil {
+		value = tftypes.NewValue(objectType, nil)
+	} else {
+		value = tftypes.NewValue(objectType, values)
+	}
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(objectType, value)
+	if err != nil {
+		t.Fatalf("unexpected error encoding value: %s", err)
+	}
+
+	return &dynamicValue
+}
+
+func TestEngineerResourceAdoptEngineerAmbiguous(t *testing.T) {
+	var updated, created []EngineerAPIModel
+	server := newAdoptServer(t, []EngineerAPIModel{
+		{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+		{Id: "K9LMQ", Name: "Ryan", Email: "RYAN@ferrets.com"},
+	}, &updated, &created)
+	defer server.Close()
+
+	r := &EngineerResource{client: NewDevopsClient(server.Client(), server.URL)}
+
+	resp := &resource.CreateResponse{}
+	_, _, ok := r.adoptEngineer(context.Background(), testAdoptModel("Ryan", "ryan@ferrets.com"), false, resp)
+
+	if !ok || resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Ambiguous Engineer Email" {
+		t.Fatalf("expected an ambiguous email error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestEngineerResourceRestoreArchived(t *testing.T) {
+	var updated, created []EngineerAPIModel
+	server := newAdoptServer(t, []EngineerAPIModel{
+		{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com", Archived: true},
+		{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+	}, &updated, &created)
+	defer server.Close()
+
+	r := &EngineerResource{client: NewDevopsClient(server.Client(), server.URL)}
+
+	resp := &resource.CreateResponse{}
+	_, _, ok := r.adoptEngineer(context.Background(), testAdoptModel("grant", "grant@google.com"), true, resp)
+
+	if ok || resp.Diagnostics.HasError() {
+		t.Errorf("expected an active engineer not to be taken over when only restoring, got %v", resp.Diagnostics)
+	}
+
+	engineer, _, ok := r.adoptEngineer(context.Background(), testAdoptModel("Ryan", "ryan@ferrets.com"), true, resp)
+
+	if !ok || resp.Diagnostics.HasError() || engineer.Id != "H3ZTR" || engineer.Archived {
+		t.Fatalf("expected H3ZTR to be restored, got %+v, %v", engineer, resp.Diagnostics)
+	}
+
+	if len(updated) != 1 || updated[0].Archived {
+		t.Errorf("expected one update clearing archived, got %+v", updated)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of the engineer on_delete attribute.
+const (
+	// onDeleteBlock refuses to delete an engineer that is on a team.
+	onDeleteBlock = "block"
+
+	// onDeleteDetach removes the engineer from its teams before deleting it.
+	onDeleteDetach = "detach"
+
+	// onDeleteCascade lets the backend remove the engineer from its teams.
+	onDeleteCascade = "cascade"
+)
+
+// Values of the engineer deletion_mode attribute.
+const (
+	// deletionModeDelete removes the engineer record.
+	deletionModeDelete = "delete"
+
+	// deletionModeArchive keeps the engineer record, marked as archived.
+	deletionModeArchive = "archive"
+)
+
+// deletionMode returns the deletion_mode, which defaults to delete.
+func deletionMode(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return deletionModeDelete
+	}
+
+	return value.ValueString()
+}
+
+// withoutArchived returns the engineers that are not archived, or all of them
+// when includeArchived is set.
+func withoutArchived(engineers []EngineerAPIModel, includeArchived bool) []EngineerAPIModel {
+	if includeArchived {
+		return engineers
+	}
+
+	active := []EngineerAPIModel{}
+	for _, engineer := range engineers {
+		if !engineer.Archived {
+			active = append(active, engineer)
+		}
+	}
+
+	return active
+}
+
+// onDeleteMode returns the on_delete mode, which defaults to block.
+func onDeleteMode(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return onDeleteBlock
+	}
+
+	return value.ValueString()
+}
+
+// engineerDeleteURL prepares the deletion of an engineer according to its
+// on_delete mode and returns the URL of the delete request. Teams the engineer
+// is on block the deletion or are detached from it first; with cascade the
+// backend is asked to clean them up.
+func engineerDeleteURL(ctx context.Context, client *DevopsClient, data EngineerResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	id := data.Id.ValueString()
+	deleteURL := client.URL("/engineers/") + id
+	mode := onDeleteMode(data.OnDelete)
+
+	if mode == onDeleteCascade {
+		return deleteURL + "?cascade=true", diags
+	}
+
+	teams, err := client.FindEngineerTeams(ctx, id)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the teams of engineer %s, got error: %s", id, err))
+		return "", diags
+	}
+
+	if len(teams) == 0 {
+		return deleteURL, diags
+	}
+
+	if mode == onDeleteBlock {
+		names := make([]string, 0, len(teams))
+		for _, team := range teams {
+			names = append(names, fmt.Sprintf("%s %q (%s)", team.Kind(), team.Name, team.Id))
+		}
+
+		diags.AddError(
+			"Engineer Still On Teams",
+			fmt.Sprintf("Engineer %s cannot be deleted because it is on %s. Remove it from those teams first, "+
+				"or set on_delete to %q or %q and apply before destroying.", id, strings.Join(names, ", "), onDeleteDetach, onDeleteCascade),
+		)
+		return "", diags
+	}
+
+	incremental := client.HasCapability(ctx, capabilityTeamMembers)
+
+	for _, team := range teams {
+		if incremental {
+			err = client.RemoveTeamMember(ctx, team.Path, team.Id, id)
+		} else {
+			// Fetch the team for its ETag so the replacement is conditional
+			var current TeamMembership
+			current, err = client.GetTeam(ctx, team.Path, team.Id)
+			if err == nil {
+				err = client.RemoveTeamEngineer(ctx, current, id)
+			}
+		}
+
+		if errors.Is(err, errPreconditionFailed) {
+			diags.Append(preconditionFailed(team.Kind(), team.Id))
+			return "", diags
+		}
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove engineer %s from %s %s, got error: %s", id, team.Kind(), team.Id, err))
+			return "", diags
+		}
+	}
+
+	return deleteURL, diags
+}
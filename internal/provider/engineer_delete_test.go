@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTeamsServer serves one ops team with engineers H3ZTR and POE5O and no dev
+// teams, and records team updates in updated. The team is served with the
+// given ETag, and updates must send If-Match "OPS01-1".
+func newTeamsServer(t *testing.T, updated *[]OpsAPIModel, etag string) *httptest.Server {
+	errs := newHandlerErrors(t)
+
+	team := OpsAPIModel{
+		Id:   "OPS01",
+		Name: "platform",
+		Engineers: []EngineerAPIModel{
+			{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+			{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/op":
+			_ = json.NewEncoder(w).Encode([]OpsAPIModel{team})
+		case r.Method == http.MethodGet && r.URL.Path == "/op/id/OPS01":
+			w.Header().Set("ETag", etag)
+			_ = json.NewEncoder(w).Encode(team)
+		case r.Method == http.MethodGet && r.URL.Path == "/dev":
+			_ = json.NewEncoder(w).Encode([]DevAPIModel{})
+		case r.Method == http.MethodPut && r.URL.Path == "/op/OPS01":
+			if r.Header.Get("If-Match") != `"OPS01-1"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+
+			var team OpsAPIModel
+			if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+				errs.fail(w, fmt.Errorf("invalid team: %w", err))
+				return
+			}
+			*updated = append(*updated, team)
+			_ = json.NewEncoder(w).Encode(team)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func testDeleteModel(id, onDelete string) EngineerResourceModel {
+	data := newEngineerResourceModel(EngineerTFModel{Id: types.StringValue(id)})
+	if onDelete != "" {
+		data.OnDelete = types.StringValue(onDelete)
+	}
+
+	return data
+}
+
+func TestEngineerDeleteURLBlock(t *testing.T) {
+	var updated []OpsAPIModel
+	server := newTeamsServer(t, &updated, `"OPS01-1"`)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	_, diags := engineerDeleteURL(context.Background(), client, testDeleteModel("H3ZTR", ""))
+
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Engineer Still On Teams" {
+		t.Fatalf("expected the delete to be blocked by default, got %v", diags)
+	}
+
+	if !strings.Contains(diags.Errors()[0].Detail(), `ops team "platform" (OPS01)`) {
+		t.Errorf("expected the error to list the team, got %q", diags.Errors()[0].Detail())
+	}
+
+	deleteURL, diags := engineerDeleteURL(context.Background(), client, testDeleteModel("K9LMQ", onDeleteBlock))
+
+	if diags.HasError() || deleteURL != server.URL+"/engineers/K9LMQ" {
+		t.Errorf("expected an engineer without teams to be deleted, got %q, %v", deleteURL, diags)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("expected no team updates, got %v", updated)
+	}
+}
+
+func TestEngineerDeleteURLDetach(t *testing.T) {
+	var updated []OpsAPIModel
+	server := newTeamsServer(t, &updated, `"OPS01-1"`)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	deleteURL, diags := engineerDeleteURL(context.Background(), client, testDeleteModel("H3ZTR", onDeleteDetach))
+
+	if diags.HasError() || deleteURL != server.URL+"/engineers/H3ZTR" {
+		t.Fatalf("unexpected result %q, %v", deleteURL, diags)
+	}
+
+	if len(updated) != 1 || len(updated[0].Engineers) != 1 || updated[0].Engineers[0].Id != "POE5O" || updated[0].Name != "platform" {
+		t.Errorf("expected H3ZTR to be removed from the team, got %+v", updated)
+	}
+}
+
+func TestEngineerDeleteURLDetachConcurrentChange(t *testing.T) {
+	var updated []OpsAPIModel
+	server := newTeamsServer(t, &updated, `"OPS01-0"`)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	_, diags := engineerDeleteURL(context.Background(), client, testDeleteModel("H3ZTR", onDeleteDetach))
+
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Concurrent Modification" {
+		t.Fatalf("expected a concurrent modification error, got %v", diags)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("expected no team updates, got %+v", updated)
+	}
+}
+
+func TestEngineerDeleteURLCascade(t *testing.T) {
+	var updated []OpsAPIModel
+	server := newTeamsServer(t, &updated, `"OPS01-1"`)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	deleteURL, diags := engineerDeleteURL(context.Background(), client, testDeleteModel("H3ZTR", onDeleteCascade))
+
+	if diags.HasError() || deleteURL != server.URL+"/engineers/H3ZTR?cascade=true" {
+		t.Errorf("expected a cascading delete, got %q, %v", deleteURL, diags)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("expected the backend to handle teams, got %v", updated)
+	}
+}
+
+func TestWithoutArchived(t *testing.T) {
+	engineers := []EngineerAPIModel{
+		{Id: "H3ZTR", Archived: true},
+		{Id: "POE5O"},
+	}
+
+	if active := withoutArchived(engineers, false); len(active) != 1 || active[0].Id != "POE5O" {
+		t.Errorf("expected only POE5O, got %+v", active)
+	}
+
+	if all := withoutArchived(engineers, true); len(all) != 2 {
+		t.Errorf("expected every engineer with include_archived, got %+v", all)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &EngineerListResource{}
+var _ list.ListResourceWithConfigure = &EngineerListResource{}
+var _ list.ListResourceWithValidateConfig = &EngineerListResource{}
+
+func NewEngineerListResource() list.ListResource {
+	return &EngineerListResource{}
+}
+
+// EngineerListResource defines the list resource implementation.
+type EngineerListResource struct {
+	client *DevopsClient
+}
+
+// EngineerListResourceModel describes the list resource config model.
+type EngineerListResourceModel struct {
+	Email EmailValue   `tfsdk:"email"`
+	Query types.String `tfsdk:"query"`
+}
+
+func (r *EngineerListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + engineerTypeName
+}
+
+func (r *EngineerListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists engineers in the backend. Without filters every engineer is returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				CustomType:          EmailType{},
+				MarkdownDescription: "Only list the engineer with this email, compared in normalized form.",
+				Optional:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Only list engineers matching this query, in the syntax of the `devops-bootcamp_engineer_search` data source.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *EngineerListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DevopsClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EngineerListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var query types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("query"), &query)...)
+
+	if resp.Diagnostics.HasError() || query.IsNull() || query.IsUnknown() {
+		return
+	}
+
+	if _, err := parseEngineerQuery(query.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Invalid Query", fmt.Sprintf("Unable to parse query, got error: %s", err))
+	}
+}
+
+func (r *EngineerListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config EngineerListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var query queryExpr
+
+	if !config.Query.IsNull() {
+		parsed, err := parseEngineerQuery(config.Query.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("query"), "Invalid Query", fmt.Sprintf("Unable to parse query, got error: %s", err))
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		query = parsed
+	}
+
+	// Filter every engineer, max_results and the query limit apply to the matches
+	apiEngineers, err := listAll[EngineerAPIModel](ctx, r.client.unbounded(), "/engineers")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read engineers, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var teams map[string][]queryTeam
+
+	if query != nil {
+		teams, err = fetchEngineerTeams(ctx, r.client)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read teams, got error: %s", err))
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	var matches []EngineerAPIModel
+
+	// Archived engineers cannot be managed, so they are not listed
+	for _, apiEngineer := range withoutArchived(apiEngineers, false) {
+		if !config.Email.IsNull() && normalizeEmail(apiEngineer.Email) != normalizeEmail(config.Email.ValueString()) {
+			continue
+		}
+
+		if query != nil && !query.eval(queryEngineer{Engineer: apiEngineer, Teams: teams[apiEngineer.Id]}) {
+			continue
+		}
+
+		matches = append(matches, apiEngineer)
+	}
+
+	matches = limitListResults(req, capResults(r.client, matches))
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, apiEngineer := range matches {
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%s <%s>", apiEngineer.Name, apiEngineer.Email)
+
+			result.Diagnostics.Append(setResourceIdentity(ctx, result.Identity, r.client, apiEngineer.Id)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, newEngineerResourceModel(EngineerTFModel{
+					Name:  types.StringValue(apiEngineer.Name),
+					Id:    types.StringValue(apiEngineer.Id),
+					Email: NewEmailValue(apiEngineer.Email),
+				}))...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// listTestResults runs the list resource r against client for the managed
+// resource m with the given config attribute values and returns the results.
+func listTestResults(t *testing.T, r list.ListResource, m resource.ResourceWithIdentity, client *DevopsClient, config map[string]tftypes.Value, limit int64) []list.ListResult {
+	t.Helper()
+
+	ctx := context.Background()
+
+	r.(list.ListResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, schemaResp)
+
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range config {
+		values[name] = value
+	}
+
+	resourceSchemaResp := &resource.SchemaResponse{}
+	m.Schema(ctx, resource.SchemaRequest{}, resourceSchemaResp)
+
+	identitySchemaResp := &resource.IdentitySchemaResponse{}
+	m.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	req := list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(configType, values),
+		},
+		IncludeResource:        true,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchemaResp.Schema,
+		ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+	}
+
+	stream := &list.ListResultsStream{}
+	r.List(ctx, req, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestEngineerListResource(t *testing.T) {
+	server := newPagingServer(t, []EngineerAPIModel{
+		{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+		{Id: "CTDSM", Name: "bob", Email: "bob@bengal.com"},
+		{Id: "M3IGD", Name: "zach", Email: "zach@bengal.com", Archived: true},
+	})
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	results := listTestResults(t, &EngineerListResource{}, &EngineerResource{}, client, map[string]tftypes.Value{
+		"email": tftypes.NewValue(tftypes.String, "Ryan+work@Ferrets.com"),
+	}, 0)
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 engineer, got %d", len(results))
+	}
+
+	var identity ResourceIdentityModel
+	if diags := results[0].Identity.Get(context.Background(), &identity); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading identity: %v", diags)
+	}
+
+	if identity.Id.ValueString() != "H3ZTR" || identity.Endpoint.ValueString() != server.URL {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	var engineer EngineerResourceModel
+	if diags := results[0].Resource.Get(context.Background(), &engineer); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading resource: %v", diags)
+	}
+
+	if engineer.Name.ValueString() != "Ryan" || results[0].DisplayName != "Ryan <ryan@ferrets.com>" {
+		t.Errorf("unexpected engineer %+v shown as %q", engineer, results[0].DisplayName)
+	}
+
+	if results := listTestResults(t, &EngineerListResource{}, &EngineerResource{}, client, nil, 2); len(results) != 2 {
+		t.Errorf("expected the limit to stop after 2 engineers, got %d", len(results))
+	}
+
+	if results := listTestResults(t, &EngineerListResource{}, &EngineerResource{}, client, nil, 0); len(results) != 3 {
+		t.Errorf("expected the archived engineer not to be listed, got %d results", len(results))
+	}
+
+	client.MaxResults = 1
+
+	results = listTestResults(t, &EngineerListResource{}, &EngineerResource{}, client, map[string]tftypes.Value{
+		"email": tftypes.NewValue(tftypes.String, "bob@bengal.com"),
+	}, 0)
+
+	if len(results) != 1 || results[0].DisplayName != "bob <bob@bengal.com>" {
+		t.Errorf("expected max_results to cap the matches rather than the fetch, got %d results", len(results))
+	}
+}
+
+func TestOpsTeamListResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]OpsAPIModel{
+			{Id: "MIGFP", Name: "ops_ferrets", Engineers: []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}},
+			{Id: "YBTQO", Name: "ops_bengal"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	results := listTestResults(t, &OpsTeamListResource{}, &OpsResource{}, client, map[string]tftypes.Value{
+		"engineer_id": tftypes.NewValue(tftypes.String, "H3ZTR"),
+	}, 0)
+
+	if len(results) != 1 || results[0].DisplayName != "ops_ferrets" {
+		t.Fatalf("expected only ops_ferrets, got %d results", len(results))
+	}
+
+	var team OpsResourceModel
+	if diags := results[0].Resource.Get(context.Background(), &team); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading resource: %v", diags)
+	}
+
+	if team.Id.ValueString() != "MIGFP" || len(team.Engineers) != 1 {
+		t.Errorf("unexpected team: %+v", team)
+	}
+
+	client.MaxResults = 1
+
+	results = listTestResults(t, &OpsTeamListResource{}, &OpsResource{}, client, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "ops_bengal"),
+	}, 0)
+
+	if len(results) != 1 || results[0].DisplayName != "ops_bengal" {
+		t.Errorf("expected max_results to cap the matches rather than the fetch, got %d results", len(results))
+	}
+}
+
+func TestDevTeamListResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]DevAPIModel{
+			{Id: "QWERT", Name: "dev_ferrets", Engineers: []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}},
+			{Id: "ASDFG", Name: "dev_bengal"},
+		})
+	}))
+	defer server.Close()
+
+	// There is no managed dev team resource, so go through the provider server
+	// to use the schemas the list resource supplies itself
+	ctx := context.Background()
+	p := providerserver.NewProtocol6(New("test")())()
+
+	schemaResp, err := p.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemaResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected schema error: %v, %v", err, schemaResp.Diagnostics)
+	}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := map[string]tftypes.Value{}
+	for name, attrType := range providerType.AttributeTypes {
+		providerConfig[name] = tftypes.NewValue(attrType, nil)
+	}
+	providerConfig["endpoint"] = tftypes.NewValue(tftypes.String, server.URL)
+
+	providerValue, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, providerConfig))
+	if err != nil {
+		t.Fatalf("unexpected error encoding provider config: %s", err)
+	}
+
+	configureResp, err := p.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerValue})
+	if err != nil || len(configureResp.Diagnostics) != 0 {
+		t.Fatalf("unexpected configure error: %v, %v", err, configureResp.Diagnostics)
+	}
+
+	configType := schemaResp.ListResourceSchemas["devops-bootcamp_dev_team"].ValueType().(tftypes.Object)
+	configValue, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, nil),
+		"engineer_id": tftypes.NewValue(tftypes.String, "H3ZTR"),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error encoding list config: %s", err)
+	}
+
+	stream, err := p.(tfprotov6.ListResourceServer).ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        "devops-bootcamp_dev_team",
+		Config:          &configValue,
+		IncludeResource: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected list error: %s", err)
+	}
+
+	var results []tfprotov6.ListResourceResult
+	for result := range stream.Results {
+		if len(result.Diagnostics) != 0 {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 1 || results[0].DisplayName != "dev_ferrets" {
+		t.Fatalf("expected only dev_ferrets, got %d results", len(results))
+	}
+
+	resourceType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name": tftypes.String,
+		"id":   tftypes.String,
+		"engineers": tftypes.Set{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"id":    tftypes.String,
+			"email": tftypes.String,
+		}}},
+	}}
+
+	resourceValue, err := results[0].Resource.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatalf("unexpected error reading resource: %s", err)
+	}
+
+	var team map[string]tftypes.Value
+	if err := resourceValue.As(&team); err != nil {
+		t.Fatalf("unexpected error reading resource: %s", err)
+	}
+
+	var id string
+	var engineers []tftypes.Value
+	if err := team["id"].As(&id); err != nil || id != "QWERT" {
+		t.Errorf("expected team id QWERT, got %q (%v)", id, err)
+	}
+	if err := team["engineers"].As(&engineers); err != nil || len(engineers) != 1 {
+		t.Errorf("expected one engineer, got %d (%v)", len(engineers), err)
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// The engineer query language is a small boolean expression language used by
+// the engineer_search data source, for example:
+//
+//	email endswith "@google.com" and not member_of("ops_ferrets")
+//
+// Grammar:
+//
+//	expr       = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | primary
+//	primary    = "(" expr ")" | call | comparison
+//	call       = ident "(" [ string { "," string } ] ")"
+//	comparison = field operator string
+//	field      = "name" | "id" | "email"
+//	operator   = "==" | "!=" | "contains" | "startswith" | "endswith" | "matches"
+//
+// Email comparisons use the normalizeEmail form of the engineer email.
+
+// queryTeam is a team an engineer belongs to.
+type queryTeam struct {
+	Kind string
+	Id   string
+	Name string
+}
+
+// queryEngineer is the record a query is evaluated against.
+type queryEngineer struct {
+	Engineer EngineerAPIModel
+	Teams    []queryTeam
+}
+
+// queryExpr is a parsed query expression.
+type queryExpr interface {
+	eval(e queryEngineer) bool
+}
+
+// queryError describes a query that could not be parsed.
+type queryError struct {
+	// Column is the 1-based position of the offending token.
+	Column  int
+	Message string
+}
+
+func (e *queryError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+type andExpr struct{ left, right queryExpr }
+
+func (x andExpr) eval(e queryEngineer) bool { return x.left.eval(e) && x.right.eval(e) }
+
+type orExpr struct{ left, right queryExpr }
+
+func (x orExpr) eval(e queryEngineer) bool { return x.left.eval(e) || x.right.eval(e) }
+
+type notExpr struct{ inner queryExpr }
+
+func (x notExpr) eval(e queryEngineer) bool { return !x.inner.eval(e) }
+
+type compareExpr struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (x compareExpr) eval(e queryEngineer) bool {
+	actual, value := "", x.value
+	switch x.field {
+	case "name":
+		actual = e.Engineer.Name
+	case "id":
+		actual = e.Engineer.Id
+	case "email":
+		// Emails are compared in their normalized form, as everywhere else in
+		// the provider. Partial values are only lowercased, they are not
+		// addresses of their own.
+		actual = normalizeEmail(e.Engineer.Email)
+		if x.op == "==" || x.op == "!=" {
+			value = normalizeEmail(value)
+		} else {
+			value = strings.ToLower(value)
+		}
+	}
+
+	switch x.op {
+	case "==":
+		return actual == value
+	case "!=":
+		return actual != value
+	case "contains":
+		return strings.Contains(actual, value)
+	case "startswith":
+		return strings.HasPrefix(actual, value)
+	case "endswith":
+		return strings.HasSuffix(actual, value)
+	case "matches":
+		return x.re.MatchString(actual)
+	}
+
+	return false
+}
+
+type callExpr struct {
+	name string
+	args []string
+}
+
+func (x callExpr) eval(e queryEngineer) bool {
+	switch x.name {
+	case "member_of":
+		for _, team := range e.Teams {
+			if team.Name == x.args[0] || team.Id == x.args[0] {
+				return true
+			}
+		}
+	case "in_ops", "in_dev":
+		kind := strings.TrimPrefix(x.name, "in_")
+		for _, team := range e.Teams {
+			if team.Kind == kind {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// queryFunctions maps each supported function to its number of arguments.
+var queryFunctions = map[string]int{
+	"member_of": 1,
+	"in_ops":    0,
+	"in_dev":    0,
+}
+
+var queryFields = map[string]bool{
+	"name":  true,
+	"id":    true,
+	"email": true,
+}
+
+var queryOperators = map[string]bool{
+	"==":         true,
+	"!=":         true,
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+	"matches":    true,
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type queryToken struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func (t queryToken) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// lexQuery splits a query into tokens.
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		column := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{tokenLParen, "(", column})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{tokenRParen, ")", column})
+			i++
+		case r == ',':
+			tokens = append(tokens, queryToken{tokenComma, ",", column})
+			i++
+		case (r == '=' || r == '!') && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, queryToken{tokenOperator, string(runes[i : i+2]), column})
+			i += 2
+		case r == '"':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &queryError{Column: column, Message: "unterminated string"}
+			}
+			tokens = append(tokens, queryToken{tokenString, sb.String(), column})
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			text := string(runes[start:i])
+			kind := tokenIdent
+			if queryOperators[text] {
+				kind = tokenOperator
+			}
+			tokens = append(tokens, queryToken{kind, text, column})
+		default:
+			return nil, &queryError{Column: column, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return append(tokens, queryToken{tokenEOF, "", len(runes) + 1}), nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+// parseEngineerQuery parses a query expression. Errors are of type *queryError.
+func parseEngineerQuery(input string) (queryExpr, error) {
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	if p.peek().kind == tokenEOF {
+		return nil, &queryError{Column: 1, Message: "query is empty"}
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "expected \"and\", \"or\" or end of query, got %s", tok.describe())
+	}
+
+	return expr, nil
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.text == word
+}
+
+func (p *queryParser) errorf(tok queryToken, format string, args ...interface{}) error {
+	return &queryError{Column: tok.column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryExpr, error) {
+	if p.isKeyword("not") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryExpr, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected \")\", got %s", closing.describe())
+		}
+		return expr, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		return p.parseComparison(tok)
+	default:
+		return nil, p.errorf(tok, "expected a field, function or \"(\", got %s", tok.describe())
+	}
+}
+
+func (p *queryParser) parseCall(name queryToken) (queryExpr, error) {
+	arity, ok := queryFunctions[name.text]
+	if !ok {
+		return nil, p.errorf(name, "unknown function %q", name.text)
+	}
+
+	// Consume the opening parenthesis.
+	p.next()
+
+	var args []string
+	for p.peek().kind != tokenRParen {
+		if len(args) > 0 {
+			if comma := p.next(); comma.kind != tokenComma {
+				return nil, p.errorf(comma, "expected \",\" or \")\", got %s", comma.describe())
+			}
+		}
+		arg := p.next()
+		if arg.kind != tokenString {
+			return nil, p.errorf(arg, "expected a string argument, got %s", arg.describe())
+		}
+		args = append(args, arg.text)
+	}
+	p.next()
+
+	if len(args) != arity {
+		return nil, p.errorf(name, "function %q expects %d argument(s), got %d", name.text, arity, len(args))
+	}
+
+	return callExpr{name: name.text, args: args}, nil
+}
+
+func (p *queryParser) parseComparison(field queryToken) (queryExpr, error) {
+	if !queryFields[field.text] {
+		return nil, p.errorf(field, "unknown field %q, expected one of name, id or email", field.text)
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, p.errorf(op, "expected an operator after %q, got %s", field.text, op.describe())
+	}
+
+	value := p.next()
+	if value.kind != tokenString {
+		return nil, p.errorf(value, "expected a string after %q, got %s", op.text, value.describe())
+	}
+
+	expr := compareExpr{field: field.text, op: op.text, value: value.text}
+
+	if op.text == "matches" {
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, p.errorf(value, "invalid regular expression: %s", err)
+		}
+		expr.re = re
+	}
+
+	return expr, nil
+}
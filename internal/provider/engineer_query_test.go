@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEngineerQueryEval(t *testing.T) {
+	grant := queryEngineer{
+		Engineer: EngineerAPIModel{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		Teams:    []queryTeam{{Kind: "dev", Id: "DEV01", Name: "dev_ferrets"}},
+	}
+	ryan := queryEngineer{
+		Engineer: EngineerAPIModel{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+		Teams:    []queryTeam{{Kind: "ops", Id: "MIGFP", Name: "ops_ferrets"}},
+	}
+
+	tests := map[string]struct {
+		query string
+		grant bool
+		ryan  bool
+	}{
+		"equals":        {query: `name == "grant"`, grant: true},
+		"not equals":    {query: `name != "grant"`, ryan: true},
+		"endswith":      {query: `email endswith "@google.com"`, grant: true},
+		"startswith":    {query: `email startswith "ryan"`, ryan: true},
+		"contains":      {query: `email contains "ferrets"`, ryan: true},
+		"email case":    {query: `email == "Ryan@Ferrets.com"`, ryan: true},
+		"email tag":     {query: `email == "ryan+ops@ferrets.com"`, ryan: true},
+		"email suffix":  {query: `email endswith "@Google.com"`, grant: true},
+		"matches":       {query: `id matches "^[A-Z0-9]{5}$"`, grant: true, ryan: true},
+		"member_of":     {query: `member_of("ops_ferrets")`, ryan: true},
+		"member_of id":  {query: `member_of("DEV01")`, grant: true},
+		"in_ops":        {query: `in_ops()`, ryan: true},
+		"in_dev":        {query: `in_dev()`, grant: true},
+		"and not":       {query: `email endswith "@google.com" and not member_of("ops_ferrets")`, grant: true},
+		"or":            {query: `name == "grant" or name == "Ryan"`, grant: true, ryan: true},
+		"precedence":    {query: `name == "nobody" and in_ops() or in_dev()`, grant: true},
+		"parentheses":   {query: `name == "nobody" and (in_ops() or in_dev())`},
+		"double not":    {query: `not not in_ops()`, ryan: true},
+		"escaped quote": {query: `name == "gr\"ant"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := parseEngineerQuery(tc.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := expr.eval(grant); got != tc.grant {
+				t.Errorf("grant: expected %t, got %t", tc.grant, got)
+			}
+
+			if got := expr.eval(ryan); got != tc.ryan {
+				t.Errorf("ryan: expected %t, got %t", tc.ryan, got)
+			}
+		})
+	}
+}
+
+func TestEngineerQueryParseErrors(t *testing.T) {
+	tests := map[string]struct {
+		query  string
+		column int
+	}{
+		"empty":               {query: "  ", column: 1},
+		"unknown field":       {query: `team == "ops"`, column: 1},
+		"missing operator":    {query: `name "grant"`, column: 6},
+		"missing value":       {query: `name ==`, column: 8},
+		"unterminated":        {query: `name == "grant`, column: 9},
+		"unknown function":    {query: `manager_of("x")`, column: 1},
+		"wrong arity":         {query: `member_of()`, column: 1},
+		"unclosed paren":      {query: `(in_ops()`, column: 10},
+		"trailing token":      {query: `in_ops() in_dev()`, column: 10},
+		"bad character":       {query: `name = "grant"`, column: 6},
+		"invalid regexp":      {query: `name matches "("`, column: 14},
+		"dangling and":        {query: `in_ops() and`, column: 13},
+		"non-string argument": {query: `member_of(ops)`, column: 11},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseEngineerQuery(tc.query)
+
+			var qErr *queryError
+			if !errors.As(err, &qErr) {
+				t.Fatalf("expected a query error, got %v", err)
+			}
+
+			if qErr.Column != tc.column {
+				t.Errorf("expected error at column %d, got %d (%s)", tc.column, qErr.Column, qErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EngineerSearchDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &EngineerSearchDataSource{}
+
+func NewEngineerSearchDataSource() datasource.DataSource {
+	return &EngineerSearchDataSource{}
+}
+
+// EngineerSearchDataSource defines the data source implementation.
+type EngineerSearchDataSource struct {
+	client *DevopsClient
+}
+
+// EngineerSearchDataSourceModel describes the data source data model.
+type EngineerSearchDataSourceModel struct {
+	Query           types.String      `tfsdk:"query"`
+	IncludeArchived types.Bool        `tfsdk:"include_archived"`
+	Engineers       []EngineerTFModel `tfsdk:"engineers"`
+}
+
+func (d *EngineerSearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_engineer_search"
+}
+
+func (d *EngineerSearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches engineers with a boolean query expression over engineer attributes and team memberships.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Query expression, for example `email endswith \"@google.com\" and not member_of(\"ops_ferrets\")`. " +
+					"Comparisons take the form `<field> <operator> \"<value>\"` where field is one of `name`, `id` or `email` and " +
+					"operator is one of `==`, `!=`, `contains`, `startswith`, `endswith` or `matches` (regular expression). " +
+					"Emails are compared in the form returned by `normalize_email`. " +
+					"The functions `member_of(\"<team name or id>\")`, `in_ops()` and `in_dev()` test team membership. " +
+					"Expressions can be combined with `and`, `or`, `not` and parentheses.",
+				Required: true,
+			},
+			"include_archived": schema.BoolAttribute{
+				MarkdownDescription: "Also search archived engineers. Defaults to false.",
+				Optional:            true,
+			},
+			"engineers": schema.ListNestedAttribute{
+				MarkdownDescription: "Engineers matching the query.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							CustomType: EmailType{},
+							Computed:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EngineerSearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DevopsClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EngineerSearchDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var query types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("query"), &query)...)
+
+	if resp.Diagnostics.HasError() || query.IsNull() || query.IsUnknown() {
+		return
+	}
+
+	if _, err := parseEngineerQuery(query.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Invalid Query", fmt.Sprintf("Unable to parse query, got error: %s", err))
+	}
+}
+
+func (d *EngineerSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EngineerSearchDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := parseEngineerQuery(state.Query.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Invalid Query", fmt.Sprintf("Unable to parse query, got error: %s", err))
+		return
+	}
+
+	// Fetch every engineer and the teams they may belong to, max_results
+	// applies to the matches
+	apiEngineers, err := listAll[EngineerAPIModel](ctx, d.client.unbounded(), "/engineers")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineers, got error: %s", err))
+		return
+	}
+
+	teams, err := fetchEngineerTeams(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read teams, got error: %s", err))
+		return
+	}
+
+	state.Engineers = []EngineerTFModel{}
+
+	for _, apiEngineer := range withoutArchived(apiEngineers, state.IncludeArchived.ValueBool()) {
+		if !query.eval(queryEngineer{Engineer: apiEngineer, Teams: teams[apiEngineer.Id]}) {
+			continue
+		}
+
+		state.Engineers = append(state.Engineers, EngineerTFModel{
+			Name:  types.StringValue(apiEngineer.Name),
+			Id:    types.StringValue(apiEngineer.Id),
+			Email: NewEmailValue(apiEngineer.Email),
+		})
+	}
+
+	state.Engineers = capResults(d.client, state.Engineers)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// fetchEngineerTeams returns the ops and dev teams of every engineer, indexed
+// by engineer ID, for evaluating engineer queries. Every team is fetched, as a
+// missing team would make queries on team membership miss engineers.
+func fetchEngineerTeams(ctx context.Context, client *DevopsClient) (map[string][]queryTeam, error) {
+	client = client.unbounded()
+
+	apiOps, err := listAll[OpsAPIModel](ctx, client, "/op")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ops: %w", err)
+	}
+
+	apiDev, err := listAll[DevAPIModel](ctx, client, "/dev")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dev: %w", err)
+	}
+
+	teams := map[string][]queryTeam{}
+	for _, op := range apiOps {
+		for _, engineer := range op.Engineers {
+			teams[engineer.Id] = append(teams[engineer.Id], queryTeam{Kind: "ops", Id: op.Id, Name: op.Name})
+		}
+	}
+	for _, dev := range apiDev {
+		for _, engineer := range dev.Engineers {
+			teams[engineer.Id] = append(teams[engineer.Id], queryTeam{Kind: "dev", Id: dev.Id, Name: dev.Name})
+		}
+	}
+
+	return teams, nil
+}
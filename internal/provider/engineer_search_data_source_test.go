@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEngineerSearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "devops-bootcamp_engineer_search" "test" {
+	query = "email endswith \"@bengal.com\" or name == \"bob\""
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify number of Engineers returned
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer_search.test", "engineers.#", "2"),
+
+					// Verify the matching engineers
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer_search.test", "engineers.0.name", "zach"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer_search.test", "engineers.0.id", "M3IGD"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer_search.test", "engineers.1.name", "bob"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer_search.test", "engineers.1.id", "CTDSM"),
+				),
+			},
+			// Parse error testing
+			{
+				Config: providerConfig + `
+data "devops-bootcamp_engineer_search" "test" {
+	query = "email endswith"
+}
+`,
+				ExpectError: regexp.MustCompile(`column 15: expected a string after "endswith"`),
+			},
+		},
+	})
+}
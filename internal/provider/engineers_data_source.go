@@ -7,10 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,12 +21,13 @@ func NewEngineerDataSource() datasource.DataSource {
 
 // EngineerDataSource defines the data source implementation.
 type EngineerDataSource struct {
-	client *http.Client
+	client *DevopsClient
 }
 
 // EngineerDataSourceModel describes the data source data model.
 type EngineerDataSourceModel struct {
-	Engineer []EngineerTFModel `tfsdk:"engineers"`
+	IncludeArchived types.Bool        `tfsdk:"include_archived"`
+	Engineer        []EngineerTFModel `tfsdk:"engineers"`
 	// ID       types.String      `tfsdk:"id"`
 }
 
@@ -41,6 +38,10 @@ func (d *EngineerDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *EngineerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"include_archived": schema.BoolAttribute{
+				MarkdownDescription: "Also return archived engineers. Defaults to false.",
+				Optional:            true,
+			},
 			"engineers": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -52,7 +53,8 @@ func (d *EngineerDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							Computed: true,
 						},
 						"email": schema.StringAttribute{
-							Computed: true,
+							CustomType: EmailType{},
+							Computed:   true,
 						},
 					},
 				},
@@ -67,12 +69,12 @@ func (d *EngineerDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -82,37 +84,29 @@ func (d *EngineerDataSource) Configure(ctx context.Context, req datasource.Confi
 }
 
 func (d *EngineerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var apiEngineers []EngineerAPIModel
 	var state EngineerDataSourceModel
 
-	// Make a call to your API to fetch the engineer data
-	httpResp, err := d.client.Get("http://localhost:8080/engineers")
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineer, got error: %s", err))
-		return
-	}
-	defer httpResp.Body.Close()
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
-	// Read the response body
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Decode the response body into the API model struct
-	err = json.Unmarshal(bodyBytes, &apiEngineers)
+	// Fetch every page from the list endpoint, max_results applies after
+	// archived engineers are filtered out
+	apiEngineers, err := listAll[EngineerAPIModel](ctx, d.client.unbounded(), "/engineers")
 	if err != nil {
-		resp.Diagnostics.AddError("Decode Error", fmt.Sprintf("Unable to decode response body, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineer, got error: %s", err))
 		return
 	}
 
 	// Convert API model to Terraform schema model and set in state
-	for _, apiEngineer := range apiEngineers {
+	for _, apiEngineer := range capResults(d.client, withoutArchived(apiEngineers, state.IncludeArchived.ValueBool())) {
 		engineer := EngineerTFModel{
 			Name:  types.StringValue(apiEngineer.Name),
 			Id:    types.StringValue(apiEngineer.Id),
-			Email: types.StringValue(apiEngineer.Email),
+			Email: NewEmailValue(apiEngineer.Email),
 		}
 		state.Engineer = append(state.Engineer, engineer)
 
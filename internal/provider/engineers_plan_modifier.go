@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure priorEngineersModifier satisfies the plan modifier interface.
+var _ planmodifier.Set = priorEngineersModifier{}
+
+// priorEngineersModifier plans the engineers of a team from prior state.
+//
+// Engineer entries are given by id or by email, and the other attributes are
+// computed. Terraform clears computed attributes when it matches set elements,
+// so a configured entry never matches its prior element on its own and every
+// plan would replace it with unknown values.
+type priorEngineersModifier struct{}
+
+// usePriorEngineers returns a plan modifier which plans each configured
+// engineer entry as the prior state element for the same engineer. Entries
+// without one, such as engineers being added, get unknown computed values.
+func usePriorEngineers() planmodifier.Set {
+	return priorEngineersModifier{}
+}
+
+func (m priorEngineersModifier) Description(ctx context.Context) string {
+	return "Engineers that are already on the team keep their values from prior state."
+}
+
+func (m priorEngineersModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m priorEngineersModifier) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	// Nothing to carry forward on create, or while the entries are unknown
+	if req.StateValue.IsNull() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	priorElements := req.StateValue.Elements()
+	prior := make([]EngineerTFModel, len(priorElements))
+
+	for i, element := range priorElements {
+		object, ok := element.(types.Object)
+		if !ok {
+			return
+		}
+
+		resp.Diagnostics.Append(object.As(ctx, &prior[i], basetypes.ObjectAsOptions{})...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	used := make([]bool, len(prior))
+	planned := make([]attr.Value, 0, len(req.ConfigValue.Elements()))
+
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			return
+		}
+
+		var entry EngineerTFModel
+
+		resp.Diagnostics.Append(object.As(ctx, &entry, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if i := matchPriorEngineer(entry, prior, used); i >= 0 {
+			used[i] = true
+			planned = append(planned, priorElements[i])
+			continue
+		}
+
+		if entry.Name.IsNull() {
+			entry.Name = types.StringUnknown()
+		}
+		if entry.Id.IsNull() {
+			entry.Id = types.StringUnknown()
+		}
+		if entry.Email.IsNull() {
+			entry.Email = NewEmailUnknown()
+		}
+
+		value, diags := types.ObjectValueFrom(ctx, object.AttributeTypes(ctx), entry)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		planned = append(planned, value)
+	}
+
+	value, diags := types.SetValue(req.ConfigValue.ElementType(ctx), planned)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.PlanValue = value
+}
+
+// matchPriorEngineer returns the index of the unused prior engineer that entry
+// refers to, or -1. Entries given by id match on id, entries given by email
+// match on the normalized email. A configured name is only used to create
+// missing engineers, so it is not compared.
+func matchPriorEngineer(entry EngineerTFModel, prior []EngineerTFModel, used []bool) int {
+	for i, engineer := range prior {
+		if used[i] {
+			continue
+		}
+
+		switch {
+		case !entry.Id.IsNull():
+			if !entry.Id.IsUnknown() && entry.Id.ValueString() == engineer.Id.ValueString() {
+				return i
+			}
+		case !entry.Email.IsNull() && !entry.Email.IsUnknown():
+			if normalizeEmail(entry.Email.ValueString()) == normalizeEmail(engineer.Email.ValueString()) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -12,35 +12,82 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EngineerResource{}
 var _ resource.ResourceWithImportState = &EngineerResource{}
+var _ resource.ResourceWithModifyPlan = &EngineerResource{}
+var _ resource.ResourceWithUpgradeState = &EngineerResource{}
+var _ resource.ResourceWithMoveState = &EngineerResource{}
+var _ resource.ResourceWithIdentity = &EngineerResource{}
+
+const (
+	engineerTypeName           = "_engineer"
+	deprecatedEngineerTypeName = "_engineer-resource"
+)
 
 func NewEngineerResource() resource.Resource {
 	return &EngineerResource{}
 }
 
+// EngineerResourceModel describes the resource data model.
+type EngineerResourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	Id            types.String `tfsdk:"id"`
+	Email         EmailValue   `tfsdk:"email"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+	OnDelete      types.String `tfsdk:"on_delete"`
+	DeletionMode  types.String `tfsdk:"deletion_mode"`
+}
+
+// newEngineerResourceModel returns the resource model of an engineer with
+// the optional settings unset.
+func newEngineerResourceModel(engineer EngineerTFModel) EngineerResourceModel {
+	return EngineerResourceModel{
+		Name:          engineer.Name,
+		Id:            engineer.Id,
+		Email:         engineer.Email,
+		AdoptExisting: types.BoolNull(),
+		OnDelete:      types.StringNull(),
+		DeletionMode:  types.StringNull(),
+	}
+}
+
 // EngineerResource defines the resource implementation.
 type EngineerResource struct {
-	client *http.Client
+	client *DevopsClient
+
+	// emails is shared by every engineer resource of a provider instance.
+	emails *emailRegistry
+
+	// deprecated is set for the engineer-resource type name alias.
+	deprecated bool
 }
 
 func (r *EngineerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_engineer-resource"
+	if r.deprecated {
+		resp.TypeName = req.ProviderTypeName + deprecatedEngineerTypeName
+		return
+	}
+
+	resp.TypeName = req.ProviderTypeName + engineerTypeName
 }
 
 func (r *EngineerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Engineer resource",
+		MarkdownDescription: "Engineer resource. Import by id or by email with an `email:grant@google.com` import ID.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -55,11 +102,40 @@ func (r *EngineerResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"email": schema.StringAttribute{
+				CustomType:          EmailType{},
 				Required:            true,
-				MarkdownDescription: "Engineer email",
+				MarkdownDescription: "Engineer email. Differences in case, surrounding whitespace or a `+tag` suffix on the local part are not treated as changes.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Take ownership of an existing engineer with the same email on create instead of creating a new one, " +
+					"renaming it if its name differs. Defaults to the provider `adopt_existing_engineers` setting.",
+				Optional: true,
+			},
+			"on_delete": schema.StringAttribute{
+				MarkdownDescription: "What to do on delete when the engineer is still on an ops or dev team: `block` fails and lists the teams, " +
+					"`detach` removes the engineer from every team first, and `cascade` lets the backend remove it. Defaults to `block`. " +
+					"A change only applies to deletes once it has been applied.",
+				Optional: true,
+				Validators: []validator.String{
+					oneOf(onDeleteBlock, onDeleteDetach, onDeleteCascade),
+				},
+			},
+			"deletion_mode": schema.StringAttribute{
+				MarkdownDescription: "How the engineer is removed on delete: `delete` removes the record, `archive` keeps it for history " +
+					"and marks it archived without changing its teams. With `archive`, creating an engineer with the email of an " +
+					"archived one restores that record. Defaults to `delete`.",
+				Optional: true,
+				Validators: []validator.String{
+					oneOf(deletionModeDelete, deletionModeArchive),
+				},
 			},
 		},
 	}
+
+	if r.deprecated {
+		resp.Schema.DeprecationMessage = "The devops-bootcamp_engineer-resource type is deprecated and will be removed in the next release. " +
+			"Use devops-bootcamp_engineer instead and add a moved block from the old address."
+	}
 }
 
 func (r *EngineerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -68,12 +144,12 @@ func (r *EngineerResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -82,8 +158,56 @@ func (r *EngineerResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+func (r *EngineerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// New engineers have no ID yet and are declared by Create
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var data EngineerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.declareEmail(data)...)
+}
+
+// declareEmail records the email of the engineer in data and warns when
+// another engineer resource of the configuration uses the same email.
+func (r *EngineerResource) declareEmail(data EngineerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.emails == nil || data.Email.IsNull() || data.Email.IsUnknown() || data.Id.IsNull() || data.Id.IsUnknown() {
+		return diags
+	}
+
+	if count := r.emails.declare(data.Email.ValueString(), data.Id.ValueString()); count > 1 {
+		diags.AddAttributeWarning(
+			path.Root("email"),
+			"Duplicate Engineer Email",
+			fmt.Sprintf("The email %q is used by %d engineer resources in this configuration. "+
+				"Each engineer resource manages a separate engineer, so the same person exists more than once.", data.Email.ValueString(), count),
+		)
+	}
+
+	return diags
+}
+
+func (r *EngineerResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *EngineerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data EngineerTFModel
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("create", "engineer"))
+		return
+	}
+
+	var data EngineerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -92,6 +216,28 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Take over an engineer with the same email instead of creating a duplicate,
+	// or restore one archived by an earlier delete
+	adopt := r.adoptExisting(data)
+	if adopt || deletionMode(data.DeletionMode) == deletionModeArchive {
+		adopted, header, found := r.adoptEngineer(ctx, data, !adopt, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if found {
+			data.Name = types.StringValue(adopted.Name)
+			data.Id = types.StringValue(adopted.Id)
+			data.Email = NewEmailValue(adopted.Email)
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+			resp.Diagnostics.Append(storeETag(ctx, resp.Private, header)...)
+			resp.Diagnostics.Append(r.declareEmail(data)...)
+			return
+		}
+	}
+
 	var engineerObject EngineerAPIModel
 	engineerObject.Name = data.Name.ValueString()
 	engineerObject.Id = data.Id.ValueString()
@@ -106,7 +252,7 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Make a POST request with JSON data
-	httpResp, err := r.client.Post("http://localhost:8080/engineers", "application/json", bytes.NewBuffer(jsonData))
+	httpResp, err := r.client.Post(r.client.URL("/engineers"), "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
 		return
@@ -133,14 +279,17 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 	// Map response body to schema and populate Computed attribute values
 	data.Name = types.StringValue(engineerRespObject.Name)
 	data.Id = types.StringValue(engineerRespObject.Id)
-	data.Email = types.StringValue(engineerRespObject.Email)
+	data.Email = NewEmailValue(engineerRespObject.Email)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, httpResp.Header)...)
+	resp.Diagnostics.Append(r.declareEmail(data)...)
 }
 
 func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data EngineerTFModel
+	var data EngineerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -150,7 +299,7 @@ func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Make a call to your API to fetch the engineer data by ID
-	httpResp, err := r.client.Get("http://localhost:8080/engineers/id/" + data.Id.ValueString())
+	httpResp, err := r.client.Get(r.client.URL("/engineers/id/") + data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineer, got error: %s", err))
 		return
@@ -172,16 +321,31 @@ func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// An archived engineer no longer exists as far as Terraform is concerned
+	if engineerRespObject.Archived {
+		tflog.Debug(ctx, "Engineer is archived, removing it from state", map[string]interface{}{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Update the data object with the response data
 	data.Name = types.StringValue(engineerRespObject.Name)
-	data.Email = types.StringValue(engineerRespObject.Email)
+	data.Email = NewEmailValue(engineerRespObject.Email)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, httpResp.Header)...)
 }
 
 func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data EngineerTFModel
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("update", "engineer"))
+		return
+	}
+
+	var data EngineerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -209,7 +373,7 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Create a new HTTP request
-	newReq, err := http.NewRequest(http.MethodPut, "http://localhost:8080/engineers/"+data.Id.ValueString(), bytes.NewBuffer(jsonData))
+	newReq, err := http.NewRequest(http.MethodPut, r.client.URL("/engineers/")+data.Id.ValueString(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create request, got error: %s", err))
 		return
@@ -218,12 +382,25 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 	// Set the Content-Type header
 	newReq.Header.Set("Content-Type", "application/json")
 
+	// Only write if the object is unchanged since it was last read
+	resp.Diagnostics.Append(setIfMatch(ctx, req.Private, newReq)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request
 	httpResp, err := r.client.Do(newReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
 		return
 	}
+
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		resp.Diagnostics.Append(preconditionFailed("engineer", data.Id.ValueString()))
+		return
+	}
+
 	// Read the HTTP response body
 	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
 	if err != nil {
@@ -245,14 +422,22 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 	// Map response body to schema and populate Computed attribute values
 	data.Name = types.StringValue(engineerRespObject.Name)
 	data.Id = types.StringValue(engineerRespObject.Id)
-	data.Email = types.StringValue(engineerRespObject.Email)
+	data.Email = NewEmailValue(engineerRespObject.Email)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, httpResp.Header)...)
 }
 
 func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data EngineerTFModel
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("delete", "engineer"))
+		return
+	}
+
+	var data EngineerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -266,6 +451,25 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 	engineerObject.Id = data.Id.ValueString()
 	engineerObject.Email = data.Email.ValueString()
 
+	method := http.MethodDelete
+	var deleteURL string
+
+	if deletionMode(data.DeletionMode) == deletionModeArchive {
+		// Keep the record and mark it archived instead
+		engineerObject.Archived = true
+		method = http.MethodPut
+		deleteURL = r.client.URL("/engineers/") + data.Id.ValueString()
+	} else {
+		// Handle teams the engineer is still on
+		var diags diag.Diagnostics
+		deleteURL, diags = engineerDeleteURL(ctx, r.client, data)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Convert data to JSON
 	jsonData, err := json.Marshal(engineerObject)
 	if err != nil {
@@ -275,7 +479,7 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	// Create a new HTTP request
-	newReq, err := http.NewRequest(http.MethodDelete, "http://localhost:8080/engineers/"+data.Id.ValueString(), bytes.NewBuffer(jsonData))
+	newReq, err := http.NewRequest(method, deleteURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create request, got error: %s", err))
 		return
@@ -284,6 +488,13 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 	// Set the Content-Type header
 	newReq.Header.Set("Content-Type", "application/json")
 
+	// Only write if the object is unchanged since it was last read
+	resp.Diagnostics.Append(setIfMatch(ctx, req.Private, newReq)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request
 	httpResp, err := r.client.Do(newReq)
 	if err != nil {
@@ -291,6 +502,11 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		resp.Diagnostics.Append(preconditionFailed("engineer", data.Id.ValueString()))
+		return
+	}
+
 	// Read the HTTP response body
 	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
 	if err != nil {
@@ -303,5 +519,33 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *EngineerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var id string
+
+	if req.ID == "" {
+		// Import blocks with an identity instead of an ID
+		identityID, diags := identityImportID(ctx, req.Identity, r.client)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id = identityID
+	} else {
+		resolvedID, err := resolveEngineerImportID(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Import Engineer",
+				fmt.Sprintf("Unable to resolve import ID %q, got error: %s", req.ID, err),
+			)
+			return
+		}
+
+		id = resolvedID
+	}
+
+	tflog.Debug(ctx, "Resolved import ID", map[string]interface{}{"import_id": req.ID, "id": id})
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, id)...)
 }
@@ -4,6 +4,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccEngineersResource(t *testing.T) {
@@ -13,7 +18,7 @@ func TestAccEngineersResource(t *testing.T) {
 			// Create and Read testing
 			{
 				Config: providerConfig + `
-resource "devops-bootcamp_engineer-resource" "test" {
+resource "devops-bootcamp_engineer" "test" {
 	name  = "grant"
 	email = "grant@google.com"
 }
@@ -21,26 +26,33 @@ resource "devops-bootcamp_engineer-resource" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 
 					// Verify first order item
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer-resource.test", "name", "grant"),
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer-resource.test", "email", "grant@google.com"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "grant"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "grant@google.com"),
 
 					// Verify dynamic values have any value set in the state.
-					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer-resource.test", "id"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer.test", "id"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "devops-bootcamp_engineer-resource.test",
+				ResourceName:      "devops-bootcamp_engineer.test",
 				ImportState:       true,
 				ImportStateVerify: true,
 				// The last_updated attribute does not exist in the HashiCups
 				// API, therefore there is no value for it during import.
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState by email testing
+			{
+				ResourceName:      "devops-bootcamp_engineer.test",
+				ImportState:       true,
+				ImportStateId:     "email:Grant@Google.com",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
-resource "devops-bootcamp_engineer-resource" "test" {
+resource "devops-bootcamp_engineer" "test" {
 	name  = "joe"
 	email = "joe@google.com"
 }
@@ -48,14 +60,91 @@ resource "devops-bootcamp_engineer-resource" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 
 					// Verify first order item
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer-resource.test", "name", "joe"),
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer-resource.test", "email", "joe@google.com"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "joe"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "joe@google.com"),
 
 					// Verify dynamic values have any value set in the state.
-					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer-resource.test", "id"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer.test", "id"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccEngineersResourceMovedFromAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			// Create with the deprecated type name
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer-resource" "test" {
+	name  = "grant"
+	email = "grant@google.com"
+}
+`,
+				Check: resource.TestCheckResourceAttrSet("devops-bootcamp_engineer-resource.test", "id"),
+			},
+			// Move to the new type name without replacing the engineer
+			{
+				Config: providerConfig + `
+moved {
+	from = devops-bootcamp_engineer-resource.test
+	to   = devops-bootcamp_engineer.test
+}
+
+resource "devops-bootcamp_engineer" "test" {
+	name  = "grant"
+	email = "grant@google.com"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_engineer.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "grant@google.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEngineersResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer" "test" {
+	name  = "grant"
+	email = "grant@google.com"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("devops-bootcamp_engineer.test", map[string]knownvalue.Check{
+						"endpoint": knownvalue.StringExact("http://localhost:8080"),
+						"id":       knownvalue.NotNull(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState("devops-bootcamp_engineer.test", tfjsonpath.New("id")),
+				},
+			},
+			// Import by identity testing
+			{
+				ResourceName:    "devops-bootcamp_engineer.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func (r *EngineerResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the email as a plain string, version 1 uses EmailType.
+		0: {
+			PriorSchema: engineerResourceSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorStateData engineerTFModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, newEngineerResourceModel(upgradeEngineerV0(priorStateData)))...)
+			},
+		},
+	}
+}
+
+func (r *EngineerResource) MoveState(ctx context.Context) []resource.StateMover {
+	// Only the current type name accepts state moved from the alias.
+	if r.deprecated {
+		return nil
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: engineerResourceSchemaV0(),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFromAlias(req, deprecatedEngineerTypeName, 0) {
+					return
+				}
+
+				var sourceStateData engineerTFModelV0
+
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &sourceStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, newEngineerResourceModel(upgradeEngineerV0(sourceStateData)))...)
+				resp.TargetPrivate = req.SourcePrivate
+			},
+		},
+		{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFromAlias(req, deprecatedEngineerTypeName, schemaResp.Schema.Version) {
+					return
+				}
+
+				var sourceStateData EngineerResourceModel
+
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &sourceStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, sourceStateData)...)
+				resp.TargetPrivate = req.SourcePrivate
+			},
+		},
+	}
+}
+
+// movedFromAlias reports whether a move request comes from the deprecated type
+// name alias of this provider at the given schema version.
+func movedFromAlias(req resource.MoveStateRequest, aliasTypeName string, version int64) bool {
+	return strings.HasSuffix(req.SourceProviderAddress, "/devops-bootcamp") &&
+		req.SourceTypeName == "devops-bootcamp"+aliasTypeName &&
+		req.SourceSchemaVersion == version &&
+		req.SourceState != nil
+}
+
+// upgradeEngineerV0 converts a version 0 engineer to the current model.
+func upgradeEngineerV0(engineer engineerTFModelV0) EngineerTFModel {
+	return EngineerTFModel{
+		Name:  engineer.Name,
+		Id:    engineer.Id,
+		Email: upgradeEmailV0(engineer.Email),
+	}
+}
+
+func engineerResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"email": schema.StringAttribute{
+				Required: true,
+			},
+		},
+	}
+}
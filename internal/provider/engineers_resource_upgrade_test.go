@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// moveTestState runs the state movers of r on source, a raw value built
+// against the source schema of that version, and returns the moved
+// state. The state is null when no mover accepted the move.
+func moveTestState(t *testing.T, r resource.ResourceWithMoveState, sourceTypeName string, version int64, source func(tftypes.Object) tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.MoveStateResponse{
+		TargetState: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	for _, mover := range r.MoveState(ctx) {
+		if mover.SourceSchema.Version != version {
+			continue
+		}
+
+		sourceType := mover.SourceSchema.Type().TerraformType(ctx).(tftypes.Object)
+
+		req := resource.MoveStateRequest{
+			SourceProviderAddress: "registry.terraform.io/liatrio/devops-bootcamp",
+			SourceTypeName:        sourceTypeName,
+			SourceSchemaVersion:   version,
+			SourceState: &tfsdk.State{
+				Schema: *mover.SourceSchema,
+				Raw:    source(sourceType),
+			},
+		}
+
+		mover.StateMover(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if !resp.TargetState.Raw.IsNull() {
+			break
+		}
+	}
+
+	return resp.TargetState
+}
+
+func TestEngineerResourceUpgradeStateV0(t *testing.T) {
+	state := upgradeTestState(t, &EngineerResource{}, 0, func(priorType tftypes.Object) tftypes.Value {
+		return tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, "POE5O"),
+			"name":  tftypes.NewValue(tftypes.String, "grant"),
+			"email": tftypes.NewValue(tftypes.String, "Grant@Google.com"),
+		})
+	})
+
+	var upgraded EngineerResourceModel
+	if diags := state.Get(context.Background(), &upgraded); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading upgraded state: %v", diags)
+	}
+
+	if upgraded.Id.ValueString() != "POE5O" || upgraded.Name.ValueString() != "grant" {
+		t.Errorf("unexpected engineer: %+v", upgraded)
+	}
+
+	if !upgraded.Email.Equal(NewEmailValue("Grant@Google.com")) {
+		t.Errorf("expected the email to be carried forward unchanged, got %s", upgraded.Email)
+	}
+}
+
+func TestEngineerResourceMoveStateFromAlias(t *testing.T) {
+	for _, version := range []int64{0, 1} {
+		state := moveTestState(t, &EngineerResource{}, "devops-bootcamp_engineer-resource", version, func(sourceType tftypes.Object) tftypes.Value {
+			source := map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, "POE5O"),
+				"name":  tftypes.NewValue(tftypes.String, "grant"),
+				"email": tftypes.NewValue(tftypes.String, "grant@google.com"),
+			}
+
+			// Attributes added since version 0 are unset
+			for name, attrType := range sourceType.AttributeTypes {
+				if _, ok := source[name]; !ok {
+					source[name] = tftypes.NewValue(attrType, nil)
+				}
+			}
+
+			return tftypes.NewValue(sourceType, source)
+		})
+
+		var moved EngineerResourceModel
+		if diags := state.Get(context.Background(), &moved); diags.HasError() {
+			t.Fatalf("version %d: unexpected diagnostics reading moved state: %v", version, diags)
+		}
+
+		if moved.Id.ValueString() != "POE5O" || moved.Name.ValueString() != "grant" || moved.Email.ValueString() != "grant@google.com" {
+			t.Errorf("version %d: unexpected engineer: %+v", version, moved)
+		}
+	}
+}
+
+func TestEngineerResourceMoveStateIgnoresOtherTypes(t *testing.T) {
+	state := moveTestState(t, &EngineerResource{}, "devops-bootcamp_ops-resource", 1, func(sourceType tftypes.Object) tftypes.Value {
+		return tftypes.NewValue(sourceType, nil)
+	})
+
+	if !state.Raw.IsNull() {
+		t.Errorf("expected no mover to accept another type, got %s", state.Raw)
+	}
+
+	if movers := (&EngineerResource{deprecated: true}).MoveState(context.Background()); len(movers) != 0 {
+		t.Errorf("expected the deprecated alias to have no movers, got %d", len(movers))
+	}
+}
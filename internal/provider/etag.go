@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// etagPrivateKey is the private state key holding the backend ETag of the
+// object as it was last read or written.
+const etagPrivateKey = "etag"
+
+// privateStateReader is implemented by the private state of resource requests.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateWriter is implemented by the private state of resource responses.
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// storeETag keeps the ETag of a backend response in private state. A response
+// without an ETag clears the stored value, so later writes are unconditional.
+func storeETag(ctx context.Context, private privateStateWriter, header http.Header) diag.Diagnostics {
+	etag := header.Get("ETag")
+	if etag == "" {
+		return private.SetKey(ctx, etagPrivateKey, nil)
+	}
+
+	value, err := json.Marshal(etag)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("JSON Error", fmt.Sprintf("Unable to marshal ETag, got error: %s", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, etagPrivateKey, value)
+}
+
+// setIfMatch makes a write conditional on the ETag kept in private state, so the
+// backend rejects it if the object changed since it was last read.
+func setIfMatch(ctx context.Context, private privateStateReader, req *http.Request) diag.Diagnostics {
+	value, diags := private.GetKey(ctx, etagPrivateKey)
+	if diags.HasError() || len(value) == 0 {
+		return diags
+	}
+
+	var etag string
+	if err := json.Unmarshal(value, &etag); err != nil {
+		diags.AddError("JSON Error", fmt.Sprintf("Unable to unmarshal ETag from private state, got error: %s", err))
+		return diags
+	}
+
+	req.Header.Set("If-Match", etag)
+
+	return diags
+}
+
+// preconditionFailed returns the error for a write the backend rejected with
+// 412 Precondition Failed because the object changed since it was last read.
+func preconditionFailed(kind, id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Concurrent Modification",
+		fmt.Sprintf("The %s %s was changed outside of this Terraform run since it was last read, so the change was not applied "+
+			"to avoid overwriting it. Refresh the state, for example with terraform apply -refresh-only, and re-plan.", kind, id),
+	)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// testPrivateState is an in-memory stand-in for resource private state.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+		return nil
+	}
+
+	p[key] = value
+	return nil
+}
+
+func TestETagRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	header := http.Header{}
+	header.Set("ETag", `W/"rev-7"`)
+
+	if diags := storeETag(ctx, private, header); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := string(private[etagPrivateKey]); got != `"W/\"rev-7\""` {
+		t.Errorf("expected the ETag to be stored as a JSON string, got %s", got)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, "http://localhost:8080/engineers/POE5O", nil)
+	if diags := setIfMatch(ctx, private, req); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := req.Header.Get("If-Match"); got != `W/"rev-7"` {
+		t.Errorf("expected If-Match to carry the stored ETag, got %q", got)
+	}
+}
+
+func TestETagClearedWithoutHeader(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{etagPrivateKey: []byte(`"rev-7"`)}
+
+	if diags := storeETag(ctx, private, http.Header{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, "http://localhost:8080/op/MIGFP", nil)
+	if diags := setIfMatch(ctx, private, req); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if got := req.Header.Get("If-Match"); got != "" {
+		t.Errorf("expected an unconditional request once the ETag is cleared, got If-Match %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// idAlphabet is the set of characters backend IDs are made of.
+const idAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// idLength is the length of every backend ID.
+const idLength = 5
+
+var idPattern = regexp.MustCompile(fmt.Sprintf("^[A-Z0-9]{%d}$", idLength))
+
+// validateID checks that id has the backend ID format, such as H3ZTR.
+func validateID(id string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%q is not a valid ID, expected %d uppercase letters or digits such as H3ZTR", id, idLength)
+	}
+
+	return nil
+}
+
+// idFromSeed deterministically derives an ID in the backend format from seed.
+func idFromSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	n := binary.BigEndian.Uint64(sum[:8])
+
+	id := make([]byte, idLength)
+	for i := range id {
+		id[i] = idAlphabet[n%uint64(len(idAlphabet))]
+		n /= uint64(len(idAlphabet))
+	}
+
+	return string(id)
+}
+
+// Ensure idValidator satisfies the validator interface.
+var _ validator.String = idValidator{}
+
+// idValidator validates that a string attribute has the backend ID format.
+type idValidator struct{}
+
+// validID returns a validator which ensures a configured ID has the backend
+// ID format. Null and unknown values are not validated.
+func validID() validator.String {
+	return idValidator{}
+}
+
+func (v idValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be %d uppercase letters or digits", idLength)
+}
+
+func (v idValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v idValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateID(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid ID", err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IDFromSeedFunction{}
+
+func NewIDFromSeedFunction() function.Function {
+	return &IDFromSeedFunction{}
+}
+
+// IDFromSeedFunction defines the function implementation.
+type IDFromSeedFunction struct{}
+
+func (f *IDFromSeedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "id_from_seed"
+}
+
+func (f *IDFromSeedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Generate an ID from a seed",
+		MarkdownDescription: "Deterministically derives an ID in the backend format of five uppercase letters or digits from the seed. " +
+			"The same seed always produces the same ID, which makes it useful for fixtures and tests.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "seed",
+				MarkdownDescription: "Seed to derive the ID from",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IDFromSeedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &seed))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, idFromSeed(seed)))
+}
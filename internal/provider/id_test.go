@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateID(t *testing.T) {
+	for _, id := range []string{"H3ZTR", "MIGFP", "POE5O", "12345"} {
+		if err := validateID(id); err != nil {
+			t.Errorf("validateID(%q): unexpected error: %s", id, err)
+		}
+	}
+
+	for _, id := range []string{"", "h3ztr", "H3ZT", "H3ZTRX", "H3-TR", " H3ZTR"} {
+		if err := validateID(id); err == nil {
+			t.Errorf("validateID(%q): expected an error", id)
+		}
+	}
+}
+
+func TestIDFromSeed(t *testing.T) {
+	first := idFromSeed("grant@google.com")
+
+	if err := validateID(first); err != nil {
+		t.Fatalf("generated ID is invalid: %s", err)
+	}
+
+	if second := idFromSeed("grant@google.com"); second != first {
+		t.Errorf("expected the same ID for the same seed, got %q and %q", first, second)
+	}
+
+	if other := idFromSeed("ben@google.com"); other == first {
+		t.Errorf("expected different IDs for different seeds, got %q for both", first)
+	}
+}
+
+func TestIDValidator(t *testing.T) {
+	tests := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"valid":   {value: types.StringValue("H3ZTR")},
+		"invalid": {value: types.StringValue("h3ztr"), expectErr: true},
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("engineers").AtListIndex(0).AtName("id"),
+				ConfigValue: tc.value,
+			}
+			resp := &validator.StringResponse{}
+
+			validID().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("expected error %t, got diagnostics: %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceIdentityModel is the identity of engineer and ops team resources: the
+// backend they live in and their ID within it.
+type ResourceIdentityModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Id       types.String `tfsdk:"id"`
+}
+
+// resourceIdentitySchema returns the identity schema shared by the engineer and
+// ops team resources.
+func resourceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"endpoint": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Endpoint of the backend that holds the object. Defaults to the provider endpoint.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Backend ID of the object.",
+			},
+		},
+	}
+}
+
+// setResourceIdentity records the identity of the object with the given ID.
+// An endpoint that is already recorded is kept, so a change to the provider
+// endpoint is not reported as a different object.
+func setResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, client *DevopsClient, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if identity == nil {
+		return diags
+	}
+
+	var data ResourceIdentityModel
+
+	if !identity.Raw.IsNull() {
+		diags.Append(identity.Get(ctx, &data)...)
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if data.Endpoint.ValueString() == "" && client != nil {
+		data.Endpoint = types.StringValue(client.Endpoint)
+	}
+
+	data.Id = types.StringValue(id)
+
+	diags.Append(identity.Set(ctx, data)...)
+
+	return diags
+}
+
+// identityImportID returns the backend ID of an import by identity. The
+// identity endpoint, when given, must be the endpoint the provider talks to,
+// since an ID is only meaningful within its backend.
+func identityImportID(ctx context.Context, identity *tfsdk.ResourceIdentity, client *DevopsClient) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var data ResourceIdentityModel
+
+	if identity == nil || identity.Raw.IsNull() {
+		diags.AddError(
+			"Missing Import ID",
+			"Either an import ID or an identity with an id is required to import this resource.",
+		)
+
+		return "", diags
+	}
+
+	diags.Append(identity.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if data.Id.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("id"),
+			"Missing Identity ID",
+			"The identity id is required to import this resource.",
+		)
+
+		return "", diags
+	}
+
+	if endpoint := data.Endpoint.ValueString(); endpoint != "" && client != nil && strings.TrimRight(endpoint, "/") != client.Endpoint {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Identity Endpoint Mismatch",
+			fmt.Sprintf("The identity endpoint %q does not match the provider endpoint %q. "+
+				"Import the object with a provider configured for that endpoint.", endpoint, client.Endpoint),
+		)
+
+		return "", diags
+	}
+
+	return data.Id.ValueString(), diags
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testIdentity returns an identity with the given endpoint and ID, or a null
+// identity when both are empty.
+func testIdentity(endpoint, id string) *tfsdk.ResourceIdentity {
+	schema := resourceIdentitySchema()
+	identityType := schema.Type().TerraformType(context.Background())
+
+	raw := tftypes.NewValue(identityType, nil)
+	if endpoint != "" || id != "" {
+		value := func(s string) tftypes.Value {
+			if s == "" {
+				return tftypes.NewValue(tftypes.String, nil)
+			}
+			return tftypes.NewValue(tftypes.String, s)
+		}
+
+		raw = tftypes.NewValue(identityType, map[string]tftypes.Value{
+			"endpoint": value(endpoint),
+			"id":       value(id),
+		})
+	}
+
+	return &tfsdk.ResourceIdentity{Schema: schema, Raw: raw}
+}
+
+func TestSetResourceIdentity(t *testing.T) {
+	ctx := context.Background()
+	client := NewDevopsClient(nil, "http://devops.example.com/")
+
+	identity := testIdentity("", "")
+	if diags := setResourceIdentity(ctx, identity, client, "POE5O"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var data ResourceIdentityModel
+	identity.Get(ctx, &data)
+
+	if data.Endpoint.ValueString() != "http://devops.example.com" || data.Id.ValueString() != "POE5O" {
+		t.Errorf("unexpected identity: %+v", data)
+	}
+
+	// An endpoint recorded earlier is kept
+	identity = testIdentity("http://localhost:8080", "POE5O")
+	if diags := setResourceIdentity(ctx, identity, client, "POE5O"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	identity.Get(ctx, &data)
+
+	if data.Endpoint.ValueString() != "http://localhost:8080" {
+		t.Errorf("expected the recorded endpoint to be kept, got %s", data.Endpoint)
+	}
+}
+
+func TestIdentityImportID(t *testing.T) {
+	client := NewDevopsClient(nil, "http://localhost:8080")
+
+	tests := map[string]struct {
+		identity *tfsdk.ResourceIdentity
+		want     string
+		err      string
+	}{
+		"id only":           {identity: testIdentity("", "POE5O"), want: "POE5O"},
+		"matching endpoint": {identity: testIdentity("http://localhost:8080/", "POE5O"), want: "POE5O"},
+		"other endpoint":    {identity: testIdentity("http://devops.example.com", "POE5O"), err: "Identity Endpoint Mismatch"},
+		"missing id":        {identity: testIdentity("http://localhost:8080", ""), err: "Missing Identity ID"},
+		"null identity":     {identity: testIdentity("", ""), err: "Missing Import ID"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := identityImportID(context.Background(), tt.identity, client)
+
+			if tt.err != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.err {
+					t.Fatalf("expected %q, got %v", tt.err, diags)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
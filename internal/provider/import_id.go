@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// importEmailPrefix selects an engineer by email instead of by ID.
+	importEmailPrefix = "email:"
+
+	// importNamePrefix selects a team by name instead of by ID.
+	importNamePrefix = "name:"
+)
+
+// resolveEngineerImportID turns an engineer import ID into a backend ID.
+// "email:<address>" is looked up by normalized email among engineers that are
+// not archived, anything else is returned unchanged.
+func resolveEngineerImportID(ctx context.Context, client *DevopsClient, importID string) (string, error) {
+	email, ok := strings.CutPrefix(importID, importEmailPrefix)
+	if !ok {
+		return importID, nil
+	}
+
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", fmt.Errorf("expected an email after %q", importEmailPrefix)
+	}
+
+	found, err := client.FindEngineersByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	// Archived engineers are gone as far as Terraform is concerned
+	engineers := withoutArchived(found, false)
+	if len(engineers) == 0 && len(found) > 0 {
+		ids := make([]string, 0, len(found))
+		for _, engineer := range found {
+			ids = append(ids, engineer.Id)
+		}
+
+		return "", fmt.Errorf("only archived engineers have email %q (IDs %s), and archived engineers cannot be imported. "+
+			"Declare the engineer with deletion_mode = %q to restore it instead", email, strings.Join(ids, ", "), deletionModeArchive)
+	}
+
+	return matchImportID(engineers, "engineer", "email", email, func(e EngineerAPIModel) (string, bool) {
+		return e.Id, true
+	})
+}
+
+// resolveOpsImportID turns an ops team import ID into a backend ID.
+// "name:<team>" is looked up by exact team name, anything else is returned
+// unchanged.
+func resolveOpsImportID(ctx context.Context, client *DevopsClient, importID string) (string, error) {
+	name, ok := strings.CutPrefix(importID, importNamePrefix)
+	if !ok {
+		return importID, nil
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("expected a team name after %q", importNamePrefix)
+	}
+
+	teams, err := listAll[OpsAPIModel](ctx, client.unbounded(), "/op")
+	if err != nil {
+		return "", err
+	}
+
+	return matchImportID(teams, "ops team", "name", name, func(t OpsAPIModel) (string, bool) {
+		return t.Id, t.Name == name
+	})
+}
+
+// matchImportID returns the ID of the only item accepted by match. It fails
+// when no item or more than one item matches, listing the candidate IDs in
+// the latter case.
+func matchImportID[T any](items []T, kind, field, value string, match func(T) (string, bool)) (string, error) {
+	var ids []string
+
+	for _, item := range items {
+		if id, ok := match(item); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s has %s %q", kind, field, value)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss have %s %q (IDs %s), import one of them by ID instead", len(ids), kind, field, value, strings.Join(ids, ", "))
+	}
+}
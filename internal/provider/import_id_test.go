@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveEngineerImportID(t *testing.T) {
+	server := newPagingServer(t, []EngineerAPIModel{
+		{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+		{Id: "CTDSM", Name: "bob", Email: "Bob@bengal.com"},
+		{Id: "M3IGD", Name: "bobby", Email: "bob+alt@bengal.com"},
+		{Id: "K9LMQ", Name: "Ryan", Email: "ryan@ferrets.com", Archived: true},
+		{Id: "BEN01", Name: "ben", Email: "ben@google.com", Archived: true},
+	})
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	client.PageSize = 1
+	client.MaxResults = 1
+
+	tests := map[string]struct {
+		importID string
+		want     string
+		err      string
+	}{
+		"passthrough":   {importID: "H3ZTR", want: "H3ZTR"},
+		"email":         {importID: "email:Grant@Google.com", want: "POE5O"},
+		"beyond cap":    {importID: "email:ryan@ferrets.com", want: "H3ZTR"},
+		"no match":      {importID: "email:nobody@google.com", err: `no engineer has email "nobody@google.com"`},
+		"many matches":  {importID: "email:bob@bengal.com", err: "2 engineers have email"},
+		"missing email": {importID: "email: ", err: "expected an email"},
+		"only archived": {importID: "email:ben@google.com", err: `only archived engineers have email "ben@google.com" (IDs BEN01)`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveEngineerImportID(context.Background(), client, tt.importID)
+
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchImportIDListsCandidates(t *testing.T) {
+	teams := []OpsAPIModel{
+		{Id: "MIGFP", Name: "ops_ferrets"},
+		{Id: "YBTQO", Name: "ops_bengal"},
+		{Id: "QWERT", Name: "ops_ferrets"},
+	}
+
+	_, err := matchImportID(teams, "ops team", "name", "ops_ferrets", func(t OpsAPIModel) (string, bool) {
+		return t.Id, t.Name == "ops_ferrets"
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "IDs MIGFP, QWERT") {
+		t.Fatalf("expected the candidate IDs in the error, got %v", err)
+	}
+}
@@ -6,14 +6,23 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 type EngineerTFModel struct {
 	Name  types.String `tfsdk:"name"`
 	Id    types.String `tfsdk:"id"`
-	Email types.String `tfsdk:"email"`
+	Email EmailValue   `tfsdk:"email"`
 }
 
 // EngineerAPIModel is used to unmarshal JSON data from the API.
 type EngineerAPIModel struct {
-	Name  string `json:"name"`
-	Id    string `json:"id"`
-	Email string `json:"email"`
+	Name     string `json:"name"`
+	Id       string `json:"id"`
+	Email    string `json:"email"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+// EngineerUpdateAPIModel is the body of an engineer update. Unlike
+// EngineerAPIModel it always sends archived, so an update can restore an
+// archived engineer.
+type EngineerUpdateAPIModel struct {
+	EngineerAPIModel
+	Archived bool `json:"archived"`
 }
 
 // Engineer is used for Terraform schema.
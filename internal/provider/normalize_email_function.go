@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeEmailFunction{}
+
+func NewNormalizeEmailFunction() function.Function {
+	return &NormalizeEmailFunction{}
+}
+
+// NormalizeEmailFunction defines the function implementation.
+type NormalizeEmailFunction struct{}
+
+func (f *NormalizeEmailFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_email"
+}
+
+func (f *NormalizeEmailFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize an engineer email",
+		MarkdownDescription: "Returns the canonical form of an engineer email, as used by the provider when it compares emails. " +
+			"Surrounding whitespace is trimmed, the address is lowercased and a `+tag` suffix on the local part is removed. " +
+			"Returns an error if the email is not valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "email",
+				MarkdownDescription: "Email to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeEmailFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &email))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateEmail(email); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid email: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalizeEmail(email)))
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccNormalizeEmailFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::devops-bootcamp::normalize_email(" Grant+ops@Google.com ")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "grant@google.com"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::devops-bootcamp::normalize_email("grant@google")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid email`),
+			},
+		},
+	})
+}
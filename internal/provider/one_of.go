@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure oneOfValidator satisfies the validator interface.
+var _ validator.String = oneOfValidator{}
+
+// oneOfValidator validates that a string attribute is one of a fixed set of
+// values.
+type oneOfValidator struct {
+	values []string
+}
+
+// oneOf returns a validator which ensures a configured string is one of
+// values. Null and unknown values are not validated.
+func oneOf(values ...string) validator.String {
+	return oneOfValidator{values: values}
+}
+
+func (v oneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of %s", v.quoted())
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Got %q, expected one of %s.", req.ConfigValue.ValueString(), v.quoted()),
+	)
+}
+
+// quoted lists the accepted values for messages.
+func (v oneOfValidator) quoted() string {
+	quoted := make([]string, 0, len(v.values))
+	for _, value := range v.values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -7,10 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,7 +21,7 @@ func NewOpsDataSource() datasource.DataSource {
 
 // OpsDataSource defines the data source implementation.
 type OpsDataSource struct {
-	client *http.Client
+	client *DevopsClient
 }
 
 // OpsDataSourceModel describes the data source data model.
@@ -61,7 +57,8 @@ func (d *OpsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 										Computed: true,
 									},
 									"email": schema.StringAttribute{
-										Computed: true,
+										CustomType: EmailType{},
+										Computed:   true,
 									},
 								},
 							},
@@ -79,12 +76,12 @@ func (d *OpsDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -94,33 +91,14 @@ func (d *OpsDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 }
 
 func (d *OpsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var apiOps []OpsAPIModel
 	var state OpsDataSourceModel
 
-	// Make a call to your API to fetch the ops data
-	httpResp, err := d.client.Get("http://localhost:8080/op")
+	// Fetch every page from the list endpoint
+	apiOps, err := listAll[OpsAPIModel](ctx, d.client, "/op")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ops, got error: %s", err))
 		return
 	}
-	defer httpResp.Body.Close()
-
-	// Read the response body
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-		return
-	}
-
-	// Print the response body
-	fmt.Println(string(bodyBytes))
-
-	// Decode the response body into the API model struct
-	err = json.Unmarshal(bodyBytes, &apiOps)
-	if err != nil {
-		resp.Diagnostics.AddError("Decode Error", fmt.Sprintf("Unable to decode response body, got error: %s", err))
-		return
-	}
 
 	// Convert API model to Terraform schema model and set in state
 	for _, apiOp := range apiOps {
@@ -133,7 +111,7 @@ func (d *OpsDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 			opState.Engineers = append(opState.Engineers, EngineerTFModel{
 				Name:  types.StringValue(apiEngineer.Name),
 				Id:    types.StringValue(apiEngineer.Id),
-				Email: types.StringValue(apiEngineer.Email),
+				Email: NewEmailValue(apiEngineer.Email),
 			})
 		}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,23 +25,56 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OpsResource{}
 var _ resource.ResourceWithImportState = &OpsResource{}
+var _ resource.ResourceWithUpgradeState = &OpsResource{}
+var _ resource.ResourceWithModifyPlan = &OpsResource{}
+var _ resource.ResourceWithValidateConfig = &OpsResource{}
+var _ resource.ResourceWithMoveState = &OpsResource{}
+var _ resource.ResourceWithIdentity = &OpsResource{}
+
+const (
+	opsTypeName           = "_ops_team"
+	deprecatedOpsTypeName = "_ops-resource"
+)
 
 func NewOpsResource() resource.Resource {
 	return &OpsResource{}
 }
 
+// NewDeprecatedOpsResource returns the ops resource under its original
+// ops-resource type name, kept for one release.
+func NewDeprecatedOpsResource() resource.Resource {
+	return &OpsResource{deprecated: true}
+}
+
 // OpsResource defines the resource implementation.
 type OpsResource struct {
-	client *http.Client
+	client *DevopsClient
+
+	// deprecated is set for the ops-resource type name alias.
+	deprecated bool
+}
+
+// OpsResourceModel describes the resource data model.
+type OpsResourceModel struct {
+	Name                   types.String      `tfsdk:"name"`
+	Id                     types.String      `tfsdk:"id"`
+	Engineers              []EngineerTFModel `tfsdk:"engineers"`
+	CreateMissingEngineers types.Bool        `tfsdk:"create_missing_engineers"`
 }
 
 func (r *OpsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_ops-resource"
+	if r.deprecated {
+		resp.TypeName = req.ProviderTypeName + deprecatedOpsTypeName
+		return
+	}
+
+	resp.TypeName = req.ProviderTypeName + opsTypeName
 }
 
 func (r *OpsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Op resource",
+		MarkdownDescription: "Op resource. Import by id or by team name with a `name:ops_ferrets` import ID.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -52,27 +86,48 @@ func (r *OpsResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"engineers": schema.ListNestedAttribute{
+			"engineers": schema.SetNestedAttribute{
+				MarkdownDescription: "Engineers on the team, each given by `id`, or by `email` with an optional `name`. " +
+					"The order they are listed in does not matter.",
 				Optional: true,
+				PlanModifiers: []planmodifier.Set{
+					usePriorEngineers(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
-							Computed: true,
+							MarkdownDescription: "Engineer name. Only set it together with `email`; it is used to create the engineer with `create_missing_engineers`.",
+							Optional:            true,
+							Computed:            true,
 						},
 						"id": schema.StringAttribute{
-							Required: true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
+							MarkdownDescription: "Engineer id. The engineer must exist.",
+							Optional:            true,
+							Computed:            true,
+							Validators: []validator.String{
+								validID(),
 							},
 						},
 						"email": schema.StringAttribute{
-							Computed: true,
+							MarkdownDescription: "Engineer email, used to look the engineer up when `id` is not set.",
+							CustomType:          EmailType{},
+							Optional:            true,
+							Computed:            true,
 						},
 					},
 				},
 			},
+			"create_missing_engineers": schema.BoolAttribute{
+				MarkdownDescription: "Create engineers listed by `email` and `name` that do not exist yet, instead of failing. Defaults to false.",
+				Optional:            true,
+			},
 		},
 	}
+
+	if r.deprecated {
+		resp.Schema.DeprecationMessage = "The devops-bootcamp_ops-resource type is deprecated and will be removed in the next release. " +
+			"Use devops-bootcamp_ops_team instead and add a moved block from the old address."
+	}
 }
 
 func (r *OpsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -81,12 +136,12 @@ func (r *OpsResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*DevopsClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -95,58 +150,76 @@ func (r *OpsResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
-func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data OpsTFModel
+func (r *OpsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var engineers types.Set
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	/* Step 1 Create Engineers */
+	resp.Diagnostics.Append(validateEngineerReferences(ctx, engineers, path.Root("engineers"))...)
+	resp.Diagnostics.Append(validateUniqueEngineers(engineers, path.Root("engineers"))...)
+}
+
+func (r *OpsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to verify when the resource is being destroyed or the provider
+	// has not been configured yet.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
 
-	var apiEngineers []EngineerAPIModel
+	var engineers types.Set
+	var createMissing types.Bool
 
-	tflog.Debug(ctx, "Begin checking engineers", map[string]interface{}{})
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("create_missing_engineers"), &createMissing)...)
 
-	// Convert engineers to API model and make a POST request for each engineer
-	for _, engineer := range data.Engineers {
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		tflog.Debug(ctx, "Checking Engineer", map[string]interface{}{"engineerID": engineer.Id})
+	// Resolve every known engineer before anything is changed
+	resp.Diagnostics.Append(verifyEngineersExist(ctx, r.client, engineers, path.Root("engineers"), createMissing.ValueBool())...)
+}
 
-		// Check if the engineer already exists
-		httpEngineerResp, err := r.client.Get("http://localhost:8080/engineers/id/" + engineer.Id.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get engineer, got error: %s", err))
-			continue
-		}
+func (r *OpsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
 
-		// Read the HTTP response body
-		bodyEngineerBytes, err := ioutil.ReadAll(httpEngineerResp.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-			continue
-		}
+func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("create", "ops team"))
+		return
+	}
 
-		// Unmarshal the response into an Engineer struct
-		var engineerRespObject EngineerAPIModel
-		err = json.Unmarshal(bodyEngineerBytes, &engineerRespObject)
-		if err != nil {
-			resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Unable to unmarshal response body, got error: %s", err))
-			continue
-		}
+	var data OpsResourceModel
 
-		// If the engineer exists, add it to apiEngineers and skip to the next engineer
-		if httpEngineerResp.StatusCode == http.StatusOK {
-			tflog.Debug(ctx, "Engineer exists, moving on", map[string]interface{}{"engineerID": engineer.Id})
-			apiEngineers = append(apiEngineers, engineerRespObject)
-			continue
-		}
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
-		tflog.Debug(ctx, "Engineer does not exist", map[string]interface{}{"engineerID": engineer.Id})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	/* Step 1 Resolve Engineers */
+
+	var engineers types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	tflog.Debug(ctx, "Begin resolving engineers", map[string]interface{}{})
+
+	apiEngineers, diags := resolveTeamEngineers(ctx, r.client, engineers, path.Root("engineers"), data.CreateMissingEngineers.ValueBool())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -167,7 +240,7 @@ func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	// Make a POST request with JSON data
-	httpResp, err := r.client.Post("http://localhost:8080/op", "application/json", bytes.NewBuffer(jsonData))
+	httpResp, err := r.client.Post(r.client.URL("/op"), "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
 		return
@@ -198,7 +271,7 @@ func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 		data.Engineers = append(data.Engineers, EngineerTFModel{
 			Name:  types.StringValue(tfEngineer.Name),
 			Id:    types.StringValue(tfEngineer.Id),
-			Email: types.StringValue(tfEngineer.Email),
+			Email: NewEmailValue(tfEngineer.Email),
 		})
 	}
 
@@ -207,10 +280,12 @@ func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, httpResp.Header)...)
 }
 
 func (r *OpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data OpsTFModel
+	var data OpsResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -220,7 +295,7 @@ func (r *OpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	// Make a call to your API to fetch the Ops data by ID
-	httpResp, err := r.client.Get("http://localhost:8080/op/id/" + data.Id.ValueString())
+	httpResp, err := r.client.Get(r.client.URL("/op/id/") + data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Ops, got error: %s", err))
 		return
@@ -242,6 +317,11 @@ func (r *OpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	// Report engineers added or removed in the backend since the last read
+	if warning, ok := membershipDriftWarning("ops team", OpsRespObject.Name, data.Engineers, OpsRespObject.Engineers); ok {
+		resp.Diagnostics.Append(warning)
+	}
+
 	// // Update the data object with the response data
 	data.Name = types.StringValue(OpsRespObject.Name)
 	data.Id = types.StringValue(OpsRespObject.Id)
@@ -253,16 +333,24 @@ func (r *OpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		data.Engineers = append(data.Engineers, EngineerTFModel{
 			Name:  types.StringValue(tfEngineer.Name),
 			Id:    types.StringValue(tfEngineer.Id),
-			Email: types.StringValue(tfEngineer.Email),
+			Email: NewEmailValue(tfEngineer.Email),
 		})
 	}
 
 	// // Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, httpResp.Header)...)
 }
 
 func (r *OpsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data OpsTFModel
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("update", "ops team"))
+		return
+	}
+
+	var data OpsResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -280,91 +368,114 @@ func (r *OpsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	OpsObject.Name = data.Name.ValueString()
 	OpsObject.Id = data.Id.ValueString()
 
-	// Convert engineers to API model and make a POST request for each engineer
-	for _, engineer := range data.Engineers {
+	/* Step 1 Resolve Engineers */
+
+	var engineers types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiEngineers, diags := resolveTeamEngineers(ctx, r.client, engineers, path.Root("engineers"), data.CreateMissingEngineers.ValueBool())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	OpsObject.Engineers = apiEngineers
+
+	/* Step 2 Update Ops */
+
+	var prior OpsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var OpsRespObject OpsAPIModel
+	var respHeader http.Header
+
+	// Apply membership changes one engineer at a time when the backend has
+	// member endpoints, so concurrent changes to other members are kept. A
+	// rename still needs the full PUT.
+	if prior.Name.ValueString() == OpsObject.Name && r.client.HasCapability(ctx, capabilityTeamMembers) {
+		added, removed := diffTeamEngineerIDs(prior.Engineers, apiEngineers)
 
-		tflog.Debug(ctx, "Checking Engineer", map[string]interface{}{"engineerID": engineer.Id})
+		tflog.Debug(ctx, "Updating ops team members", map[string]interface{}{"added": added, "removed": removed})
 
-		// Check if the engineer already exists
-		httpEngineerResp, err := r.client.Get("http://localhost:8080/engineers/id/" + engineer.Id.ValueString())
+		err := applyTeamMemberChanges(ctx, r.client, "/op", OpsObject.Id, added, removed)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get engineer, got error: %s", err))
-			continue
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update ops team members, got error: %s", err))
+			return
 		}
 
-		// Read the HTTP response body
-		bodyEngineerBytes, err := ioutil.ReadAll(httpEngineerResp.Body)
+		OpsRespObject, respHeader, err = r.client.GetOps(ctx, OpsObject.Id)
 		if err != nil {
-			resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-			continue
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Ops, got error: %s", err))
+			return
 		}
-
-		// Unmarshal the response into an Engineer struct
-		var engineerRespObject EngineerAPIModel
-		err = json.Unmarshal(bodyEngineerBytes, &engineerRespObject)
+	} else {
+		// Convert data to JSON
+		jsonData, err := json.Marshal(OpsObject)
 		if err != nil {
-			resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Unable to unmarshal response body, got error: %s", err))
-			continue
+			tflog.Debug(ctx, "Error marshalling Engineer JSON", map[string]interface{}{"error": err.Error()})
+			return
+		} else {
+			tflog.Debug(ctx, "Marshalled Engineer JSON", map[string]interface{}{"engineerData": string(jsonData)})
 		}
 
-		// If the engineer exists, add it to apiEngineers and skip to the next engineer
-		if httpEngineerResp.StatusCode == http.StatusOK {
-			tflog.Debug(ctx, "Engineer exists, moving on", map[string]interface{}{"engineerID": engineer.Id})
-
-			//Store the engineer in the API model
-			OpsObject.Engineers = append(OpsObject.Engineers, engineerRespObject)
-
-			continue
+		// Create a new HTTP request
+		newReq, err := http.NewRequest(http.MethodPut, r.client.URL("/op/")+OpsObject.Id, bytes.NewBuffer(jsonData))
+		if err != nil {
+			resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create request, got error: %s", err))
+			return
 		}
 
-		tflog.Debug(ctx, "Engineer does not exist", map[string]interface{}{"engineerID": engineer.Id})
+		// Set the Content-Type header
+		newReq.Header.Set("Content-Type", "application/json")
 
-		return
-	}
+		// Only write if the object is unchanged since it was last read
+		resp.Diagnostics.Append(setIfMatch(ctx, req.Private, newReq)...)
 
-	/* Step 2 Create Ops */
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	// Convert data to JSON
-	jsonData, err := json.Marshal(OpsObject)
-	if err != nil {
-		tflog.Debug(ctx, "Error marshalling Engineer JSON", map[string]interface{}{"error": err.Error()})
-		return
-	} else {
-		tflog.Debug(ctx, "Marshalled Engineer JSON", map[string]interface{}{"engineerData": string(jsonData)})
-	}
+		// Send the request
+		httpResp, err := r.client.Do(newReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
+			return
+		}
 
-	// Create a new HTTP request
-	newReq, err := http.NewRequest(http.MethodPut, "http://localhost:8080/op/"+OpsObject.Id, bytes.NewBuffer(jsonData))
-	if err != nil {
-		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create request, got error: %s", err))
-		return
-	}
+		if httpResp.StatusCode == http.StatusPreconditionFailed {
+			resp.Diagnostics.Append(preconditionFailed("ops team", data.Id.ValueString()))
+			return
+		}
 
-	// Set the Content-Type header
-	newReq.Header.Set("Content-Type", "application/json")
+		// Read the HTTP response body
+		bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
+			return
+		}
 
-	// Send the request
-	httpResp, err := r.client.Do(newReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-		return
-	}
-	// Read the HTTP response body
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-		return
-	}
+		// Log the response body
+		tflog.Debug(ctx, "Response Body", map[string]interface{}{"body": string(bodyBytes)})
 
-	// Log the response body
-	tflog.Debug(ctx, "Response Body", map[string]interface{}{"body": string(bodyBytes)})
+		// Unmarshal the response into an Ops struct
+		err = json.Unmarshal(bodyBytes, &OpsRespObject)
+		if err != nil {
+			resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Unable to unmarshal response body, got error: %s", err))
+			return
+		}
 
-	// Unmarshal the response into an Ops struct
-	var OpsRespObject OpsAPIModel
-	err = json.Unmarshal(bodyBytes, &OpsRespObject)
-	if err != nil {
-		resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Unable to unmarshal response body, got error: %s", err))
-		return
+		respHeader = httpResp.Header
 	}
 
 	// Clear existing engineers before appending new ones
@@ -374,7 +485,7 @@ func (r *OpsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		data.Engineers = append(data.Engineers, EngineerTFModel{
 			Name:  types.StringValue(tfEngineer.Name),
 			Id:    types.StringValue(tfEngineer.Id),
-			Email: types.StringValue(tfEngineer.Email),
+			Email: NewEmailValue(tfEngineer.Email),
 		})
 	}
 
@@ -383,11 +494,19 @@ func (r *OpsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, data.Id.ValueString())...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, respHeader)...)
 
 }
 
 func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data OpsTFModel
+	// Refuse writes before any request is sent
+	if r.client.ReadOnly {
+		resp.Diagnostics.Append(readOnlyError("delete", "ops team"))
+		return
+	}
+
+	var data OpsResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -410,7 +529,7 @@ func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 
 	// Create a new HTTP request
-	newReq, err := http.NewRequest(http.MethodDelete, "http://localhost:8080/op/"+data.Id.ValueString(), bytes.NewBuffer(jsonData))
+	newReq, err := http.NewRequest(http.MethodDelete, r.client.URL("/op/")+data.Id.ValueString(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		resp.Diagnostics.AddError("Request Error", fmt.Sprintf("Unable to create request, got error: %s", err))
 		return
@@ -419,6 +538,13 @@ func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	// Set the Content-Type header
 	newReq.Header.Set("Content-Type", "application/json")
 
+	// Only write if the object is unchanged since it was last read
+	resp.Diagnostics.Append(setIfMatch(ctx, req.Private, newReq)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request
 	httpResp, err := r.client.Do(newReq)
 	if err != nil {
@@ -426,6 +552,11 @@ func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		resp.Diagnostics.Append(preconditionFailed("ops team", data.Id.ValueString()))
+		return
+	}
+
 	// Read the HTTP response body
 	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
 	if err != nil {
@@ -438,5 +569,33 @@ func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *OpsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var id string
+
+	if req.ID == "" {
+		// Import blocks with an identity instead of an ID
+		identityID, diags := identityImportID(ctx, req.Identity, r.client)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id = identityID
+	} else {
+		resolvedID, err := resolveOpsImportID(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Import Ops Team",
+				fmt.Sprintf("Unable to resolve import ID %q, got error: %s", req.ID, err),
+			)
+			return
+		}
+
+		id = resolvedID
+	}
+
+	tflog.Debug(ctx, "Resolved import ID", map[string]interface{}{"import_id": req.ID, "id": id})
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.client, id)...)
 }
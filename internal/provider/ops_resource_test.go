@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccOpsResource(t *testing.T) {
@@ -15,19 +17,19 @@ func TestAccOpsResource(t *testing.T) {
 				Config: providerConfig + `
 
 
-				resource "devops-bootcamp_engineer-resource" "ben" {
+				resource "devops-bootcamp_engineer" "ben" {
 					name  = "ben"
 					email = "ben@google.com"
 				  }
-				  resource "devops-bootcamp_engineer-resource" "wick" {
+				  resource "devops-bootcamp_engineer" "wick" {
 					name = "wick"
 					email = "wick@google.com"
 				  }
-resource "devops-bootcamp_ops-resource" "test" {
+resource "devops-bootcamp_ops_team" "test" {
 	name  = "ops_example_1"
 	engineers = [
 		{
-			id = devops-bootcamp_engineer-resource.ben.id
+			id = devops-bootcamp_engineer.ben.id
 		},
 	]
 }
@@ -35,46 +37,54 @@ resource "devops-bootcamp_ops-resource" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 
 					// Verify ops name and amount of engineers
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "name", "ops_example_1"),
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.#", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops_team.test", "name", "ops_example_1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops_team.test", "engineers.#", "1"),
 
 					// Verify dynamic values have any value set in the state.
-					resource.TestCheckResourceAttrSet("devops-bootcamp_ops-resource.test", "id"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_ops_team.test", "id"),
 
-					// Verify the first & second engineer to ensure all attributes are set
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.0.name", "ben"),
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.0.email", "ben@google.com"),
-
-					resource.TestCheckResourceAttrSet("devops-bootcamp_ops-resource.test", "engineers.0.id"),
+					// Verify the engineer to ensure all attributes are set
+					resource.TestCheckTypeSetElemNestedAttrs("devops-bootcamp_ops_team.test", "engineers.*", map[string]string{
+						"name":  "ben",
+						"email": "ben@google.com",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_ops_team.test", "engineers.*.id", "devops-bootcamp_engineer.ben", "id"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "devops-bootcamp_ops-resource.test",
+				ResourceName:      "devops-bootcamp_ops_team.test",
 				ImportState:       true,
 				ImportStateVerify: true,
 				// The last_updated attribute does not exist in the HashiCups
 				// API, therefore there is no value for it during import.
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState by name testing
+			{
+				ResourceName:      "devops-bootcamp_ops_team.test",
+				ImportState:       true,
+				ImportStateId:     "name:ops_example_1",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
 
-				  resource "devops-bootcamp_engineer-resource" "wick" {
+				  resource "devops-bootcamp_engineer" "wick" {
 					name = "wick"
 					email = "wick@google.com"
 				  }
-				  resource "devops-bootcamp_engineer-resource" "ben" {
+				  resource "devops-bootcamp_engineer" "ben" {
 					name  = "ben"
 					email = "ben@google.com"
 				  }
-resource "devops-bootcamp_ops-resource" "test" {
+resource "devops-bootcamp_ops_team" "test" {
 	name  = "ops_example_2"
 	engineers = [
 
 		{
-			id = devops-bootcamp_engineer-resource.wick.id
+			id = devops-bootcamp_engineer.wick.id
 		}
 
 	]
@@ -83,19 +93,86 @@ resource "devops-bootcamp_ops-resource" "test" {
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify ops name and amount of engineers
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "name", "ops_example_2"),
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.#", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops_team.test", "name", "ops_example_2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops_team.test", "engineers.#", "1"),
 
 					// Verify dynamic values have any value set in the state.
-					resource.TestCheckResourceAttrSet("devops-bootcamp_ops-resource.test", "id"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_ops_team.test", "id"),
 
-					// Verify the first engineer to ensure all attributes are set
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.0.name", "wick"),
-					resource.TestCheckResourceAttr("devops-bootcamp_ops-resource.test", "engineers.0.email", "wick@google.com"),
-					resource.TestCheckResourceAttrSet("devops-bootcamp_ops-resource.test", "engineers.0.id"),
+					// Verify the engineer to ensure all attributes are set
+					resource.TestCheckTypeSetElemNestedAttrs("devops-bootcamp_ops_team.test", "engineers.*", map[string]string{
+						"name":  "wick",
+						"email": "wick@google.com",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_ops_team.test", "engineers.*.id", "devops-bootcamp_engineer.wick", "id"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccOpsResourceMissingEngineers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing engineers fail the plan
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_ops_team" "test" {
+	name  = "ops_example_2"
+	engineers = [
+		{
+			name  = "nobody"
+			email = "nobody@google.com"
+		},
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`No engineer has email nobody@google.com`),
+			},
+			// Missing engineers are created on request
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_ops_team" "test" {
+	name                     = "ops_example_2"
+	create_missing_engineers = true
+	engineers = [
+		{
+			name  = "nobody"
+			email = "nobody@google.com"
+		},
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_ops_team.test", "engineers.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("devops-bootcamp_ops_team.test", "engineers.*", map[string]string{
+						"name":  "nobody",
+						"email": "nobody@google.com",
+					}),
+				),
+			},
+			// Engineers given by email plan no changes once applied
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_ops_team" "test" {
+	name                     = "ops_example_2"
+	create_missing_engineers = true
+	engineers = [
+		{
+			name  = "nobody"
+			email = "Nobody@Google.com"
+		},
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// opsResourceModelV0 describes version 0 of the ops resource state, where
+// engineers was an ordered list.
+type opsResourceModelV0 struct {
+	Name      types.String        `tfsdk:"name"`
+	Id        types.String        `tfsdk:"id"`
+	Engineers []engineerTFModelV0 `tfsdk:"engineers"`
+}
+
+// engineerTFModelV0 describes an engineer before emails used EmailType.
+type engineerTFModelV0 struct {
+	Name  types.String `tfsdk:"name"`
+	Id    types.String `tfsdk:"id"`
+	Email types.String `tfsdk:"email"`
+}
+
+func (r *OpsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored engineers as a list, version 1 stores them as a set.
+		0: {
+			PriorSchema: opsResourceSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorStateData opsResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeOpsV0(priorStateData))...)
+			},
+		},
+	}
+}
+
+func (r *OpsResource) MoveState(ctx context.Context) []resource.StateMover {
+	// Only the current type name accepts state moved from the alias.
+	if r.deprecated {
+		return nil
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: opsResourceSchemaV0(),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFromAlias(req, deprecatedOpsTypeName, 0) {
+					return
+				}
+
+				var sourceStateData opsResourceModelV0
+
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &sourceStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeOpsV0(sourceStateData))...)
+				resp.TargetPrivate = req.SourcePrivate
+			},
+		},
+		{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFromAlias(req, deprecatedOpsTypeName, schemaResp.Schema.Version) {
+					return
+				}
+
+				var sourceStateData OpsResourceModel
+
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &sourceStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, sourceStateData)...)
+				resp.TargetPrivate = req.SourcePrivate
+			},
+		},
+	}
+}
+
+// upgradeOpsV0 converts a version 0 ops team to the current model.
+func upgradeOpsV0(team opsResourceModelV0) OpsResourceModel {
+	upgraded := OpsResourceModel{
+		Name: team.Name,
+		Id:   team.Id,
+	}
+
+	for _, engineer := range team.Engineers {
+		upgraded.Engineers = append(upgraded.Engineers, upgradeEngineerV0(engineer))
+	}
+
+	return upgraded
+}
+
+// upgradeEmailV0 carries a version 0 email forward unchanged as an EmailValue.
+func upgradeEmailV0(email types.String) EmailValue {
+	return EmailValue{StringValue: email}
+}
+
+func opsResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"engineers": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"id": schema.StringAttribute{
+							Required: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeTestState runs the state upgrader for version on prior, a raw value
+// built against the upgrader's prior schema, and returns the upgraded state.
+func upgradeTestState(t *testing.T, r resource.ResourceWithUpgradeState, version int64, prior func(tftypes.Object) tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader registered for version %d", version)
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    prior(priorType),
+		},
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.State
+}
+
+func TestOpsResourceUpgradeStateV0(t *testing.T) {
+	state := upgradeTestState(t, &OpsResource{}, 0, func(priorType tftypes.Object) tftypes.Value {
+		engineersType := priorType.AttributeTypes["engineers"].(tftypes.List)
+
+		engineer := func(id, name, email string) tftypes.Value {
+			return tftypes.NewValue(engineersType.ElementType, map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, id),
+				"name":  tftypes.NewValue(tftypes.String, name),
+				"email": tftypes.NewValue(tftypes.String, email),
+			})
+		}
+
+		return tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "MIGFP"),
+			"name": tftypes.NewValue(tftypes.String, "ops_ferrets"),
+			"engineers": tftypes.NewValue(engineersType, []tftypes.Value{
+				engineer("H3ZTR", "Ryan", "ryan@ferrets.com"),
+				engineer("POE5O", "grant", "grant@google.com"),
+			}),
+		})
+	})
+
+	var upgraded OpsResourceModel
+	if diags := state.Get(context.Background(), &upgraded); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading upgraded state: %v", diags)
+	}
+
+	if upgraded.Id.ValueString() != "MIGFP" || upgraded.Name.ValueString() != "ops_ferrets" {
+		t.Errorf("unexpected team: %+v", upgraded)
+	}
+
+	if len(upgraded.Engineers) != 2 {
+		t.Fatalf("expected 2 engineers, got %d", len(upgraded.Engineers))
+	}
+
+	ids := map[string]string{}
+	for _, e := range upgraded.Engineers {
+		ids[e.Id.ValueString()] = e.Email.ValueString()
+	}
+
+	if ids["H3ZTR"] != "ryan@ferrets.com" || ids["POE5O"] != "grant@google.com" {
+		t.Errorf("unexpected engineers: %v", ids)
+	}
+}
+
+func TestOpsResourceUpgradeStateV0NoEngineers(t *testing.T) {
+	state := upgradeTestState(t, &OpsResource{}, 0, func(priorType tftypes.Object) tftypes.Value {
+		return tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"id":        tftypes.NewValue(tftypes.String, "YBTQO"),
+			"name":      tftypes.NewValue(tftypes.String, "ops_bengal"),
+			"engineers": tftypes.NewValue(priorType.AttributeTypes["engineers"], nil),
+		})
+	})
+
+	var upgraded OpsResourceModel
+	if diags := state.Get(context.Background(), &upgraded); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading upgraded state: %v", diags)
+	}
+
+	if upgraded.Id.ValueString() != "YBTQO" || upgraded.Engineers != nil {
+		t.Errorf("unexpected team: %+v", upgraded)
+	}
+}
+
+func TestOpsResourceMoveStateFromAliasV0(t *testing.T) {
+	state := moveTestState(t, &OpsResource{}, "devops-bootcamp_ops-resource", 0, func(sourceType tftypes.Object) tftypes.Value {
+		engineersType := sourceType.AttributeTypes["engineers"].(tftypes.List)
+
+		return tftypes.NewValue(sourceType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "MIGFP"),
+			"name": tftypes.NewValue(tftypes.String, "ops_ferrets"),
+			"engineers": tftypes.NewValue(engineersType, []tftypes.Value{
+				tftypes.NewValue(engineersType.ElementType, map[string]tftypes.Value{
+					"id":    tftypes.NewValue(tftypes.String, "H3ZTR"),
+					"name":  tftypes.NewValue(tftypes.String, "Ryan"),
+					"email": tftypes.NewValue(tftypes.String, "ryan@ferrets.com"),
+				}),
+			}),
+		})
+	})
+
+	var moved OpsResourceModel
+	if diags := state.Get(context.Background(), &moved); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading moved state: %v", diags)
+	}
+
+	if moved.Id.ValueString() != "MIGFP" || len(moved.Engineers) != 1 || moved.Engineers[0].Id.ValueString() != "H3ZTR" {
+		t.Errorf("unexpected team: %+v", moved)
+	}
+}
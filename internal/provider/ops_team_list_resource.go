@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &OpsTeamListResource{}
+var _ list.ListResourceWithConfigure = &OpsTeamListResource{}
+
+func NewOpsTeamListResource() list.ListResource {
+	return &OpsTeamListResource{}
+}
+
+// OpsTeamListResource defines the list resource implementation.
+type OpsTeamListResource struct {
+	client *DevopsClient
+}
+
+// OpsTeamListResourceModel describes the list resource config model.
+type OpsTeamListResourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	EngineerId types.String `tfsdk:"engineer_id"`
+}
+
+func (r *OpsTeamListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + opsTypeName
+}
+
+func (r *OpsTeamListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists ops teams in the backend. Without filters every ops team is returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only list the ops team with this name.",
+				Optional:            true,
+			},
+			"engineer_id": schema.StringAttribute{
+				MarkdownDescription: "Only list ops teams that this engineer is a member of.",
+				Optional:            true,
+				Validators: []validator.String{
+					validID(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OpsTeamListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DevopsClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *DevopsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OpsTeamListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config OpsTeamListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Filter every team, max_results and the query limit apply to the matches
+	apiOps, err := listAll[OpsAPIModel](ctx, r.client.unbounded(), "/op")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read ops, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var matches []OpsAPIModel
+
+	for _, apiOp := range apiOps {
+		if !config.Name.IsNull() && apiOp.Name != config.Name.ValueString() {
+			continue
+		}
+
+		if !config.EngineerId.IsNull() && !hasEngineer(apiOp.Engineers, config.EngineerId.ValueString()) {
+			continue
+		}
+
+		matches = append(matches, apiOp)
+	}
+
+	matches = limitListResults(req, capResults(r.client, matches))
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, apiOp := range matches {
+			result := req.NewListResult(ctx)
+			result.DisplayName = apiOp.Name
+
+			result.Diagnostics.Append(setResourceIdentity(ctx, result.Identity, r.client, apiOp.Id)...)
+
+			if req.IncludeResource {
+				data := OpsResourceModel{
+					Name: types.StringValue(apiOp.Name),
+					Id:   types.StringValue(apiOp.Id),
+				}
+
+				for _, apiEngineer := range apiOp.Engineers {
+					data.Engineers = append(data.Engineers, EngineerTFModel{
+						Name:  types.StringValue(apiEngineer.Name),
+						Id:    types.StringValue(apiEngineer.Id),
+						Email: NewEmailValue(apiEngineer.Email),
+					})
+				}
+
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// limitListResults trims matches to the result limit of the list request.
+func limitListResults[T any](req list.ListRequest, matches []T) []T {
+	if req.Limit > 0 && int64(len(matches)) > req.Limit {
+		return matches[:req.Limit]
+	}
+
+	return matches
+}
+
+// hasEngineer reports whether engineers contains the engineer with the given ID.
+func hasEngineer(engineers []EngineerAPIModel, id string) bool {
+	for _, engineer := range engineers {
+		if engineer.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
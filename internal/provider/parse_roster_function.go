@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseRosterFunction{}
+
+// rosterEntryAttrTypes is the object type returned for each roster entry.
+var rosterEntryAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"email": types.StringType,
+	"team":  types.StringType,
+}
+
+func NewParseRosterFunction() function.Function {
+	return &ParseRosterFunction{}
+}
+
+// ParseRosterFunction defines the function implementation.
+type ParseRosterFunction struct{}
+
+func (f *ParseRosterFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_roster"
+}
+
+func (f *ParseRosterFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a CSV or YAML roster into engineer objects",
+		MarkdownDescription: "Parses roster text into a list of `{name, email, team}` objects. " +
+			"CSV rosters need a header row with `name` and `email` columns and an optional `team` column. " +
+			"YAML rosters are a list of mappings with the same keys, either at the top level or under an `engineers` key. " +
+			"Emails are validated with the same rules as `valid_email` and must be unique, so the result can be keyed by email, " +
+			"for example `for_each = { for e in provider::devops-bootcamp::parse_roster(file(\"roster.csv\"), \"csv\") : e.email => e }`. " +
+			"`team` is null when not provided. All row errors are reported together with their line numbers.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "content",
+				MarkdownDescription: "Roster text",
+			},
+			function.StringParameter{
+				Name:                "format",
+				MarkdownDescription: "Roster format, either `csv` or `yaml`",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: rosterEntryAttrTypes},
+		},
+	}
+}
+
+func (f *ParseRosterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content, format string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &content, &format))
+
+	if resp.Error != nil {
+		return
+	}
+
+	entries, err := parseRoster(content, format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid roster:\n"+err.Error())
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(entries))
+
+	for _, entry := range entries {
+		team := types.StringNull()
+		if entry.Team != "" {
+			team = types.StringValue(entry.Team)
+		}
+
+		element, diags := types.ObjectValue(rosterEntryAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(entry.Name),
+			"email": types.StringValue(entry.Email),
+			"team":  team,
+		})
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+		elements = append(elements, element)
+	}
+
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ListValue(types.ObjectType{AttrTypes: rosterEntryAttrTypes}, elements)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
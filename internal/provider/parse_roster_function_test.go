@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParseRosterFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+	roster = provider::devops-bootcamp::parse_roster(<<-EOT
+		name,email,team
+		grant,grant@google.com,ops_ferrets
+		ben,ben@google.com,
+		EOT
+	, "csv")
+}
+
+output "count" {
+	value = length(local.roster)
+}
+
+output "team" {
+	value = local.roster[0].team
+}
+
+output "keys" {
+	value = join(",", keys({ for e in local.roster : e.email => e }))
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("count", "2"),
+					resource.TestCheckOutput("team", "ops_ferrets"),
+					resource.TestCheckOutput("keys", "ben@google.com,grant@google.com"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::devops-bootcamp::parse_roster("name,email\ngrant,grant@google", "csv")
+}
+`,
+				ExpectError: regexp.MustCompile(`line 2: invalid email`),
+			},
+		},
+	})
+}
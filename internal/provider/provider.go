@@ -5,28 +5,42 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure DevopsProvider satisfies various provider interfaces.
 var _ provider.Provider = &DevopsProvider{}
 var _ provider.ProviderWithFunctions = &DevopsProvider{}
+var _ provider.ProviderWithListResources = &DevopsProvider{}
 
 // DevopsProvider defines the provider implementation.
 type DevopsProvider struct {
 	version string
+
+	// emails tracks engineer emails declared in the configuration.
+	emails *emailRegistry
 }
 
 // DevopsProviderModel describes the provider data model.
 type DevopsProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint   types.String `tfsdk:"endpoint"`
+	PageSize   types.Int64  `tfsdk:"page_size"`
+	MaxResults types.Int64  `tfsdk:"max_results"`
+
+	AdoptExistingEngineers types.Bool `tfsdk:"adopt_existing_engineers"`
+	ReadOnly               types.Bool `tfsdk:"read_only"`
+	DryRun                 types.Bool `tfsdk:"dry_run"`
 }
 
 func (p *DevopsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,9 +52,33 @@ func (p *DevopsProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "DevOps provider attribute",
+				MarkdownDescription: "Base URL of the DevOps API. Defaults to `http://localhost:8080`. When it is only known after apply, work is deferred on Terraform versions that support deferred actions.",
+				Optional:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of items requested per page from list endpoints. Defaults to 100.",
 				Optional:            true,
 			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of items returned by any list call. Unlimited when unset.",
+				Optional:            true,
+			},
+			"adopt_existing_engineers": schema.BoolAttribute{
+				MarkdownDescription: "Default for the engineer `adopt_existing` attribute: adopt an existing engineer with the same email on create instead of creating a duplicate. Defaults to false.",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Never change the backend. Creating, updating or deleting any resource fails before a request is sent, " +
+					"and only GET and HEAD requests are made. Meant for data source only workspaces. Defaults to false.",
+				Optional: true,
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Simulate every write instead of sending it. Creates and updates are served from memory with " +
+					"deterministic IDs shaped like real ones, reads see the simulated objects, and each request that would have been " +
+					"sent is logged at INFO level. The resulting state refers to objects that do not exist, so only use it with a " +
+					"throwaway state. Defaults to false.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -54,19 +92,132 @@ func (p *DevopsProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
+	// Values that come from resources not yet applied are unknown during plan.
+	// Falling back to the defaults would plan against the wrong backend, so
+	// defer the work until they are known, or fail where that is unsupported.
+	if unknown := unknownProviderAttributes(data); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Debug(ctx, "Deferring until provider configuration is known", map[string]interface{}{"attributes": unknown})
+
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+			return
+		}
+
+		for _, name := range unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown Provider Configuration",
+				fmt.Sprintf("The provider cannot be configured because the %s attribute is not known until apply, "+
+					"for example because it refers to a resource that has not been created yet. "+
+					"Apply that resource first with -target, or use a Terraform version that supports deferred actions.", name),
+			)
+		}
+		return
+	}
+
+	httpClient := http.DefaultClient
+	if data.DryRun.ValueBool() {
+		httpClient = dryRunHTTPClient(httpClient, data.Endpoint.ValueString())
+
+		resp.Diagnostics.AddWarning(
+			"Dry Run",
+			"The provider is configured with dry_run = true. No changes are sent to the backend, and resources "+
+				"created or updated in this run get simulated IDs. Do not keep the resulting state.",
+		)
+	}
+	if data.ReadOnly.ValueBool() {
+		httpClient = readOnlyHTTPClient(httpClient)
+	}
+
+	client := NewDevopsClient(httpClient, data.Endpoint.ValueString())
+	client.ReadOnly = data.ReadOnly.ValueBool()
+
+	if !data.PageSize.IsNull() {
+		if data.PageSize.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("page_size"),
+				"Invalid Page Size",
+				"The page_size attribute must be at least 1.",
+			)
+		}
+		client.PageSize = data.PageSize.ValueInt64()
+	}
+
+	if !data.MaxResults.IsNull() {
+		if data.MaxResults.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_results"),
+				"Invalid Max Results",
+				"The max_results attribute must be at least 1.",
+			)
+		}
+		client.MaxResults = data.MaxResults.ValueInt64()
+	}
+
+	client.AdoptExistingEngineers = data.AdoptExistingEngineers.ValueBool()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Example client configuration for data sources and resources
-	client := http.DefaultClient
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+}
+
+// unknownProviderAttributes returns the names of the provider attributes whose
+// values are not known yet.
+func unknownProviderAttributes(data DevopsProviderModel) []string {
+	var unknown []string
+
+	if data.Endpoint.IsUnknown() {
+		unknown = append(unknown, "endpoint")
+	}
+
+	if data.PageSize.IsUnknown() {
+		unknown = append(unknown, "page_size")
+	}
+
+	if data.MaxResults.IsUnknown() {
+		unknown = append(unknown, "max_results")
+	}
+
+	if data.AdoptExistingEngineers.IsUnknown() {
+		unknown = append(unknown, "adopt_existing_engineers")
+	}
+
+	if data.ReadOnly.IsUnknown() {
+		unknown = append(unknown, "read_only")
+	}
+
+	if data.DryRun.IsUnknown() {
+		unknown = append(unknown, "dry_run")
+	}
+
+	return unknown
 }
 
 func (p *DevopsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		NewEngineerResource,
+		func() resource.Resource {
+			return &EngineerResource{emails: p.emails}
+		},
+		// The engineer-resource type name, kept for one release
+		func() resource.Resource {
+			return &EngineerResource{emails: p.emails, deprecated: true}
+		},
 		NewOpsResource,
+		NewDeprecatedOpsResource,
+	}
+}
+
+func (p *DevopsProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewEngineerListResource,
+		NewOpsTeamListResource,
+		NewDevTeamListResource,
 	}
 }
 
@@ -76,17 +227,26 @@ func (p *DevopsProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewOpsDataSource,
 		NewDevDataSource,
 		NewDevopsDataSource,
+		NewEngineerSearchDataSource,
 	}
 }
 
 func (p *DevopsProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewNormalizeEmailFunction,
+		NewValidEmailFunction,
+		NewParseRosterFunction,
+		NewRosterDiffFunction,
+		NewValidIDFunction,
+		NewIDFromSeedFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &DevopsProvider{
 			version: version,
+			emails:  newEmailRegistry(),
 		}
 	}
 }
@@ -1,8 +1,14 @@
 package provider
 
 import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -21,8 +27,73 @@ var (
 	// testAccProtoV6ProviderFactories are used to instantiate a provider during
 	// acceptance testing. The factory function will be invoked for every Terraform
 	// CLI command executed to create a provider server to which the CLI can
-	// reattach.
+	// reattach. Each server gets its own provider instance, as it would from
+	// Terraform, so state kept by the provider does not leak between tests.
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-		"devops-bootcamp": providerserver.NewProtocol6WithError(New("test")()),
+		"devops-bootcamp": func() (tfprotov6.ProviderServer, error) {
+			return providerserver.NewProtocol6WithError(New("test")())()
+		},
 	}
 )
+
+// configureTestProvider configures the provider with an unknown endpoint.
+func configureTestProvider(t *testing.T, deferralAllowed bool) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	config := map[string]tftypes.Value{}
+	for name, attrType := range configType.AttributeTypes {
+		config[name] = tftypes.NewValue(attrType, nil)
+	}
+	config["endpoint"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(configType, config),
+		},
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{
+			DeferralAllowed: deferralAllowed,
+		},
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, req, resp)
+
+	return resp
+}
+
+func TestProviderConfigureUnknownEndpointDefers(t *testing.T) {
+	resp := configureTestProvider(t, true)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+		t.Errorf("expected a deferral for unknown provider configuration, got %+v", resp.Deferred)
+	}
+
+	if resp.ResourceData != nil || resp.DataSourceData != nil {
+		t.Error("expected no client while the endpoint is unknown")
+	}
+}
+
+func TestProviderConfigureUnknownEndpointWithoutDeferral(t *testing.T) {
+	resp := configureTestProvider(t, false)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unknown Provider Configuration" {
+		t.Fatalf("expected an unknown configuration error, got %v", resp.Diagnostics)
+	}
+
+	if resp.Deferred != nil || resp.ResourceData != nil {
+		t.Error("expected neither a deferral nor a client")
+	}
+}
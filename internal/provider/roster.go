@@ -0,0 +1,403 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rosterColumns lists the supported roster fields.
+var rosterColumns = map[string]bool{
+	"name":  true,
+	"email": true,
+	"team":  true,
+}
+
+// rosterRequiredColumns lists the fields every roster must provide.
+var rosterRequiredColumns = []string{"name", "email"}
+
+// rosterEntry is a single engineer parsed from a roster.
+type rosterEntry struct {
+	Name  string
+	Email string
+	Team  string
+	Line  int
+}
+
+// rosterError is a problem found on a single roster line.
+type rosterError struct {
+	Line    int
+	Message string
+}
+
+// rosterErrors collects the problems found while parsing a roster.
+type rosterErrors []rosterError
+
+func (e *rosterErrors) add(line int, format string, args ...interface{}) {
+	*e = append(*e, rosterError{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the collected problems ordered by line, or nil if there are none.
+func (e rosterErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(e, func(i, j int) bool { return e[i].Line < e[j].Line })
+
+	lines := make([]string, 0, len(e))
+	for _, rosterErr := range e {
+		lines = append(lines, fmt.Sprintf("line %d: %s", rosterErr.Line, rosterErr.Message))
+	}
+
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+// parseRoster parses CSV or YAML roster text. All row level problems are
+// reported together, each prefixed with its line number.
+func parseRoster(content string, format string) ([]rosterEntry, error) {
+	var entries []rosterEntry
+	var errs rosterErrors
+
+	switch strings.ToLower(format) {
+	case "csv":
+		entries = parseRosterCSV(content, &errs)
+	case "yaml", "yml":
+		entries = parseRosterYAML(content, &errs)
+	default:
+		return nil, fmt.Errorf("unsupported roster format %q, expected \"csv\" or \"yaml\"", format)
+	}
+
+	validateRosterEntries(entries, &errs)
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func parseRosterCSV(content string, errs *rosterErrors) []rosterEntry {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		errs.add(1, "roster is empty")
+		return nil
+	}
+	if err != nil {
+		errs.add(csvErrorLine(err, 1), "%s", csvErrorText(err))
+		return nil
+	}
+
+	headerLine, _ := reader.FieldPos(0)
+	columns := map[string]int{}
+
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+
+		if !rosterColumns[column] {
+			errs.add(headerLine, "unknown column %q, expected name, email and optionally team", column)
+			continue
+		}
+
+		if _, ok := columns[column]; ok {
+			errs.add(headerLine, "duplicate column %q", column)
+			continue
+		}
+
+		columns[column] = i
+	}
+
+	for _, column := range rosterRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			errs.add(headerLine, "missing required column %q", column)
+		}
+	}
+
+	if len(*errs) > 0 {
+		return nil
+	}
+
+	var entries []rosterEntry
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs.add(csvErrorLine(err, headerLine), "%s", csvErrorText(err))
+			if errors.Is(err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := rosterEntry{Line: line}
+
+		entry.Name = strings.TrimSpace(record[columns["name"]])
+		entry.Email = strings.TrimSpace(record[columns["email"]])
+		if i, ok := columns["team"]; ok {
+			entry.Team = strings.TrimSpace(record[i])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// csvErrorLine returns the line reported by a csv.ParseError.
+func csvErrorLine(err error, fallback int) int {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line
+	}
+	return fallback
+}
+
+// csvErrorText returns the underlying error text without the position prefix.
+func csvErrorText(err error) string {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// yamlErrorLinePattern matches the position prefix of yaml syntax errors.
+var yamlErrorLinePattern = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// yamlErrorLine returns the line reported by a yaml syntax error.
+func yamlErrorLine(err error, fallback int) int {
+	if match := yamlErrorLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		if line, convErr := strconv.Atoi(match[1]); convErr == nil {
+			return line
+		}
+	}
+	return fallback
+}
+
+// yamlErrorText returns the yaml error text without the position prefix.
+func yamlErrorText(err error) string {
+	return strings.TrimPrefix(yamlErrorLinePattern.ReplaceAllString(err.Error(), ""), "yaml: ")
+}
+
+func parseRosterYAML(content string, errs *rosterErrors) []rosterEntry {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		errs.add(yamlErrorLine(err, 1), "%s", yamlErrorText(err))
+		return nil
+	}
+
+	if len(document.Content) == 0 {
+		errs.add(1, "roster is empty")
+		return nil
+	}
+
+	root := document.Content[0]
+
+	// Accept either a top level list or a mapping with an engineers list.
+	if root.Kind == yaml.MappingNode {
+		var list *yaml.Node
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "engineers" {
+				list = root.Content[i+1]
+			}
+		}
+		if list == nil {
+			errs.add(root.Line, "expected a list of engineers or an \"engineers\" key")
+			return nil
+		}
+		root = list
+	}
+
+	if root.Kind != yaml.SequenceNode {
+		errs.add(root.Line, "expected a list of engineers")
+		return nil
+	}
+
+	var entries []rosterEntry
+
+	for _, item := range root.Content {
+		if item.Kind != yaml.MappingNode {
+			errs.add(item.Line, "expected a mapping with name, email and optionally team")
+			continue
+		}
+
+		entry := rosterEntry{Line: item.Line}
+		seen := map[string]bool{}
+		valid := true
+
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			field := strings.ToLower(key.Value)
+
+			if !rosterColumns[field] {
+				errs.add(key.Line, "unknown field %q, expected name, email and optionally team", key.Value)
+				valid = false
+				continue
+			}
+
+			if seen[field] {
+				errs.add(key.Line, "duplicate field %q", field)
+				valid = false
+				continue
+			}
+			seen[field] = true
+
+			if value.Kind != yaml.ScalarNode {
+				errs.add(value.Line, "field %q must be a string", field)
+				valid = false
+				continue
+			}
+
+			switch field {
+			case "name":
+				entry.Name = strings.TrimSpace(value.Value)
+			case "email":
+				entry.Email = strings.TrimSpace(value.Value)
+			case "team":
+				entry.Team = strings.TrimSpace(value.Value)
+			}
+		}
+
+		if valid {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// validateRosterEntries checks required values, email format and duplicates.
+func validateRosterEntries(entries []rosterEntry, errs *rosterErrors) {
+	firstSeen := map[string]int{}
+
+	for _, entry := range entries {
+		if entry.Name == "" {
+			errs.add(entry.Line, "name is required")
+		}
+
+		if entry.Email == "" {
+			errs.add(entry.Line, "email is required")
+			continue
+		}
+
+		if err := validateEmail(entry.Email); err != nil {
+			errs.add(entry.Line, "invalid email %q: %s", entry.Email, err)
+			continue
+		}
+
+		key := normalizeEmail(entry.Email)
+		if line, ok := firstSeen[key]; ok {
+			errs.add(entry.Line, "duplicate email %q, first listed on line %d", entry.Email, line)
+			continue
+		}
+		firstSeen[key] = entry.Line
+	}
+}
+
+// rosterMember identifies an engineer in a roster being compared by engineer
+// ID, normalized email or both.
+type rosterMember struct {
+	Id    string
+	Email string
+	Team  string
+}
+
+// Key returns the identifier reported for the member: the engineer ID, or the
+// normalized email when no ID is known.
+func (m rosterMember) Key() string {
+	if m.Id != "" {
+		return m.Id
+	}
+
+	return m.Email
+}
+
+// matches reports whether m and other are the same engineer. Members are
+// matched by ID when both have one, and by normalized email otherwise, so a
+// team read from the backend can be compared with a roster file without IDs.
+func (m rosterMember) matches(other rosterMember) bool {
+	if m.Id != "" && other.Id != "" {
+		return m.Id == other.Id
+	}
+
+	return m.Email != "" && m.Email == other.Email
+}
+
+// rosterDiffResult holds the keys that differ between two rosters. Members
+// present in both rosters whose team changed are reported as moved rather
+// than unchanged.
+type rosterDiffResult struct {
+	Added     []string
+	Removed   []string
+	Unchanged []string
+	Moved     []string
+}
+
+// diffRosters compares two rosters member by member. Members present in both
+// rosters are reported by ID when either side knows it. Each result slice is
+// sorted.
+func diffRosters(before, after []rosterMember) rosterDiffResult {
+	var result rosterDiffResult
+
+	matched := make([]bool, len(before))
+
+	for _, member := range after {
+		prior := -1
+		for i, candidate := range before {
+			if !matched[i] && candidate.matches(member) {
+				prior = i
+				break
+			}
+		}
+
+		if prior < 0 {
+			result.Added = append(result.Added, member.Key())
+			continue
+		}
+
+		matched[prior] = true
+
+		key := member.Key()
+		if member.Id == "" && before[prior].Id != "" {
+			key = before[prior].Id
+		}
+
+		if before[prior].Team != member.Team {
+			result.Moved = append(result.Moved, key)
+		} else {
+			result.Unchanged = append(result.Unchanged, key)
+		}
+	}
+
+	for i, member := range before {
+		if !matched[i] {
+			result.Removed = append(result.Removed, member.Key())
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Strings(result.Unchanged)
+	sort.Strings(result.Moved)
+
+	return result
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RosterDiffFunction{}
+
+// rosterDiffAttrTypes is the object type returned by roster_diff.
+var rosterDiffAttrTypes = map[string]attr.Type{
+	"added":     types.SetType{ElemType: types.StringType},
+	"removed":   types.SetType{ElemType: types.StringType},
+	"unchanged": types.SetType{ElemType: types.StringType},
+	"moved":     types.SetType{ElemType: types.StringType},
+}
+
+func NewRosterDiffFunction() function.Function {
+	return &RosterDiffFunction{}
+}
+
+// RosterDiffFunction defines the function implementation.
+type RosterDiffFunction struct{}
+
+func (f *RosterDiffFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "roster_diff"
+}
+
+func (f *RosterDiffFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare two team rosters",
+		MarkdownDescription: "Compares two rosters and returns an object with `added`, `removed`, `unchanged` and `moved` sets. " +
+			"Each roster is a list of engineer IDs or engineer objects, such as an ops team `engineers` list or the output of `parse_roster`. " +
+			"Two objects are the same engineer when their `id` matches, or, when either of them has no `id`, when their normalized `email` matches. " +
+			"The sets contain the `id` of each engineer, or the normalized `email` when no `id` is known. " +
+			"Engineers present in both rosters whose `team` attribute differs are reported as `moved` instead of `unchanged`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "before",
+				MarkdownDescription: "Current roster",
+			},
+			function.DynamicParameter{
+				Name:                "after",
+				MarkdownDescription: "Proposed roster",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: rosterDiffAttrTypes,
+		},
+	}
+}
+
+func (f *RosterDiffFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var before, after types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &before, &after))
+
+	if resp.Error != nil {
+		return
+	}
+
+	beforeMembers, funcErr := rosterMembersFromDynamic(0, before)
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	afterMembers, funcErr := rosterMembersFromDynamic(1, after)
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil {
+		return
+	}
+
+	diff := diffRosters(beforeMembers, afterMembers)
+
+	result, diags := types.ObjectValue(rosterDiffAttrTypes, map[string]attr.Value{
+		"added":     stringSetValue(diff.Added),
+		"removed":   stringSetValue(diff.Removed),
+		"unchanged": stringSetValue(diff.Unchanged),
+		"moved":     stringSetValue(diff.Moved),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// rosterMembersFromDynamic converts a list, tuple or set of engineer IDs or
+// engineer objects into roster members.
+func rosterMembersFromDynamic(argument int64, value types.Dynamic) ([]rosterMember, *function.FuncError) {
+	var elements []attr.Value
+
+	switch v := value.UnderlyingValue().(type) {
+	case basetypes.TupleValue:
+		elements = v.Elements()
+	case basetypes.ListValue:
+		elements = v.Elements()
+	case basetypes.SetValue:
+		elements = v.Elements()
+	default:
+		return nil, function.NewArgumentFuncError(argument, fmt.Sprintf("Expected a list of engineer IDs or engineer objects, got: %s", value.UnderlyingValue().Type(context.Background())))
+	}
+
+	members := make([]rosterMember, 0, len(elements))
+	seen := map[string]int{}
+
+	for i, element := range elements {
+		var member rosterMember
+
+		switch v := element.(type) {
+		case basetypes.StringValue:
+			member.Id = strings.TrimSpace(v.ValueString())
+		case basetypes.ObjectValue:
+			attributes := v.Attributes()
+			member.Id = objectStringAttribute(attributes, "id")
+			member.Email = normalizeEmail(objectStringAttribute(attributes, "email"))
+			member.Team = objectStringAttribute(attributes, "team")
+		default:
+			return nil, function.NewArgumentFuncError(argument, fmt.Sprintf("Element %d must be an engineer ID or an engineer object, got: %s", i, element.Type(context.Background())))
+		}
+
+		if member.Id == "" && member.Email == "" {
+			return nil, function.NewArgumentFuncError(argument, fmt.Sprintf("Element %d has neither an id nor an email", i))
+		}
+
+		for _, key := range []string{member.Id, member.Email} {
+			if key == "" {
+				continue
+			}
+
+			if first, ok := seen[key]; ok {
+				return nil, function.NewArgumentFuncError(argument, fmt.Sprintf("Element %d duplicates element %d (%s)", i, first, key))
+			}
+			seen[key] = i
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// objectStringAttribute returns the trimmed value of a string attribute, or an
+// empty string when the attribute is missing, null or not a string.
+func objectStringAttribute(attributes map[string]attr.Value, name string) string {
+	value, ok := attributes[name].(basetypes.StringValue)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+
+	return strings.TrimSpace(value.ValueString())
+}
+
+// stringSetValue converts keys into a set of strings.
+func stringSetValue(keys []string) types.Set {
+	elements := make([]attr.Value, 0, len(keys))
+	for _, key := range keys {
+		elements = append(elements, types.StringValue(key))
+	}
+
+	return types.SetValueMust(types.StringType, elements)
+}
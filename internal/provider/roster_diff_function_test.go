@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRosterDiffFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Engineer IDs
+			{
+				Config: `
+locals {
+	diff = provider::devops-bootcamp::roster_diff(["H3ZTR", "M3IGD"], ["M3IGD", "CTDSM"])
+}
+
+output "added" {
+	value = join(",", local.diff.added)
+}
+
+output "removed" {
+	value = join(",", local.diff.removed)
+}
+
+output "unchanged" {
+	value = join(",", local.diff.unchanged)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("added", "CTDSM"),
+					resource.TestCheckOutput("removed", "H3ZTR"),
+					resource.TestCheckOutput("unchanged", "M3IGD"),
+				),
+			},
+			// Engineer objects
+			{
+				Config: `
+locals {
+	diff = provider::devops-bootcamp::roster_diff(
+		[{ email = "grant@google.com", team = "ops_ferrets" }, { email = "ben@google.com", team = "ops_ferrets" }],
+		[{ email = "Grant@Google.com", team = "ops_bengal" }, { email = "ben@google.com", team = "ops_ferrets" }],
+	)
+}
+
+output "moved" {
+	value = join(",", local.diff.moved)
+}
+
+output "unchanged" {
+	value = join(",", local.diff.unchanged)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("moved", "grant@google.com"),
+					resource.TestCheckOutput("unchanged", "ben@google.com"),
+				),
+			},
+			// Team engineers with IDs against a parsed roster without IDs
+			{
+				Config: `
+locals {
+	diff = provider::devops-bootcamp::roster_diff(
+		[{ id = "H3ZTR", name = "Ryan", email = "ryan@ferrets.com" }, { id = "M3IGD", name = "zach", email = "zach@bengal.com" }],
+		provider::devops-bootcamp::parse_roster("name,email\nRyan,Ryan@Ferrets.com\ngrant,grant@google.com", "csv"),
+	)
+}
+
+output "added" {
+	value = join(",", local.diff.added)
+}
+
+output "removed" {
+	value = join(",", local.diff.removed)
+}
+
+output "unchanged" {
+	value = join(",", local.diff.unchanged)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("added", "grant@google.com"),
+					resource.TestCheckOutput("removed", "M3IGD"),
+					resource.TestCheckOutput("unchanged", "H3ZTR"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::devops-bootcamp::roster_diff(["H3ZTR", "H3ZTR"], [])
+}
+`,
+				ExpectError: regexp.MustCompile(`Element 1 duplicates element 0`),
+			},
+		},
+	})
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRosterCSV(t *testing.T) {
+	content := `# exported from HR
+name,email,team
+grant, grant@google.com ,ops_ferrets
+ben,ben@google.com,
+`
+
+	entries, err := parseRoster(content, "csv")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []rosterEntry{
+		{Name: "grant", Email: "grant@google.com", Team: "ops_ferrets", Line: 3},
+		{Name: "ben", Email: "ben@google.com", Line: 4},
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], entries[i])
+		}
+	}
+}
+
+func TestParseRosterYAML(t *testing.T) {
+	tests := map[string]string{
+		"list": `
+- name: grant
+  email: grant@google.com
+  team: ops_ferrets
+- name: ben
+  email: ben@google.com
+`,
+		"engineers key": `
+engineers:
+  - name: grant
+    email: grant@google.com
+    team: ops_ferrets
+  - name: ben
+    email: ben@google.com
+`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			entries, err := parseRoster(content, "yaml")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(entries) != 2 {
+				t.Fatalf("expected 2 entries, got %d", len(entries))
+			}
+
+			if entries[0].Team != "ops_ferrets" || entries[1].Email != "ben@google.com" || entries[1].Team != "" {
+				t.Errorf("unexpected entries: %+v", entries)
+			}
+		})
+	}
+}
+
+func TestParseRosterErrors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		format  string
+		errors  []string
+	}{
+		"unknown format": {
+			content: "name,email",
+			format:  "json",
+			errors:  []string{`unsupported roster format "json"`},
+		},
+		"missing column": {
+			content: "name,team\ngrant,ops",
+			format:  "csv",
+			errors:  []string{`line 1: missing required column "email"`},
+		},
+		"unknown column": {
+			content: "name,email,manager\ngrant,grant@google.com,bob",
+			format:  "csv",
+			errors:  []string{`line 1: unknown column "manager"`},
+		},
+		"row errors": {
+			content: "name,email\ngrant,grant@google\n,ben@google.com\nben,ben@google.com,extra\nbob,BOB@google.com\nbobby,bob+1@google.com",
+			format:  "csv",
+			errors: []string{
+				`line 2: invalid email "grant@google"`,
+				`line 3: name is required`,
+				`line 4: wrong number of fields`,
+				`line 6: duplicate email "bob+1@google.com", first listed on line 5`,
+			},
+		},
+		"yaml fields": {
+			content: "- name: grant\n  email: grant@google.com\n  manager: bob\n- name: ben\n",
+			format:  "yaml",
+			errors: []string{
+				`line 3: unknown field "manager"`,
+				`line 4: email is required`,
+			},
+		},
+		"yaml syntax": {
+			content: "- name: grant\n  email: grant@google.com\n- name: ben\n  email: ben@google.com\n team: ops\n",
+			format:  "yaml",
+			errors:  []string{`line 4: did not find expected '-' indicator`},
+		},
+		"yaml shape": {
+			content: "name: grant\n",
+			format:  "yaml",
+			errors:  []string{`line 1: expected a list of engineers or an "engineers" key`},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseRoster(tc.content, tc.format)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tc.errors) {
+				t.Fatalf("expected %d errors, got %d:\n%s", len(tc.errors), len(lines), err)
+			}
+
+			for i, expected := range tc.errors {
+				if !strings.HasPrefix(lines[i], expected) {
+					t.Errorf("error %d: expected prefix %q, got %q", i, expected, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffRosters(t *testing.T) {
+	before := []rosterMember{
+		{Id: "H3ZTR", Team: "ops_ferrets"},
+		{Id: "M3IGD", Team: "ops_ferrets"},
+		{Id: "CTDSM", Team: "ops_ferrets"},
+	}
+	after := []rosterMember{
+		{Id: "POE5O", Team: "ops_ferrets"},
+		{Id: "CTDSM", Team: "ops_bengal"},
+		{Id: "H3ZTR", Team: "ops_ferrets"},
+	}
+
+	result := diffRosters(before, after)
+
+	expected := map[string][]string{
+		"added":     {"POE5O"},
+		"removed":   {"M3IGD"},
+		"unchanged": {"H3ZTR"},
+		"moved":     {"CTDSM"},
+	}
+	got := map[string][]string{
+		"added":     result.Added,
+		"removed":   result.Removed,
+		"unchanged": result.Unchanged,
+		"moved":     result.Moved,
+	}
+
+	for name, keys := range expected {
+		if strings.Join(got[name], ",") != strings.Join(keys, ",") {
+			t.Errorf("%s: expected %v, got %v", name, keys, got[name])
+		}
+	}
+}
+
+func TestDiffRostersMixedIdentifiers(t *testing.T) {
+	// A team read from the backend has IDs, a parsed roster file only emails
+	before := []rosterMember{
+		{Id: "H3ZTR", Email: "ryan@ferrets.com"},
+		{Id: "M3IGD", Email: "zach@bengal.com"},
+		{Id: "CTDSM", Email: "bob@bengal.com"},
+	}
+	after := []rosterMember{
+		{Email: "ryan@ferrets.com"},
+		{Email: "bob@bengal.com", Team: "ops_bengal"},
+		{Email: "grant@google.com"},
+		{Id: "K9LMQ", Email: "zach@bengal.com"},
+	}
+
+	result := diffRosters(before, after)
+
+	expected := map[string][]string{
+		"added":     {"K9LMQ", "grant@google.com"},
+		"removed":   {"M3IGD"},
+		"unchanged": {"H3ZTR"},
+		"moved":     {"CTDSM"},
+	}
+	got := map[string][]string{
+		"added":     result.Added,
+		"removed":   result.Removed,
+		"unchanged": result.Unchanged,
+		"moved":     result.Moved,
+	}
+
+	for name, keys := range expected {
+		if strings.Join(got[name], ",") != strings.Join(keys, ",") {
+			t.Errorf("%s: expected %v, got %v", name, keys, got[name])
+		}
+	}
+}
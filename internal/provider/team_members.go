@@ -0,0 +1,432 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// teamEngineerLookup finds the engineers a team's engineers set refers to.
+// Entries name an engineer by id, or by email when the id is not set. The
+// engineer list needed for email lookups is fetched at most once.
+type teamEngineerLookup struct {
+	client  *DevopsClient
+	byEmail map[string][]EngineerAPIModel
+}
+
+// find returns the engineer an entry refers to and whether it exists.
+func (l *teamEngineerLookup) find(ctx context.Context, entry EngineerTFModel) (EngineerAPIModel, bool, error) {
+	if !entry.Id.IsNull() {
+		return l.client.GetEngineer(ctx, entry.Id.ValueString())
+	}
+
+	if l.byEmail == nil {
+		engineers, err := listAll[EngineerAPIModel](ctx, l.client.unbounded(), "/engineers")
+		if err != nil {
+			return EngineerAPIModel{}, false, err
+		}
+
+		l.byEmail = map[string][]EngineerAPIModel{}
+		for _, engineer := range engineers {
+			key := normalizeEmail(engineer.Email)
+			l.byEmail[key] = append(l.byEmail[key], engineer)
+		}
+	}
+
+	matches := l.byEmail[normalizeEmail(entry.Email.ValueString())]
+
+	switch len(matches) {
+	case 0:
+		return EngineerAPIModel{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	default:
+		return EngineerAPIModel{}, false, fmt.Errorf("%d engineers have email %q, set the id of the one to add instead", len(matches), entry.Email.ValueString())
+	}
+}
+
+// teamEngineerEntry decodes a known element of a team's engineers set. It
+// returns false for entries that cannot be looked up yet because the
+// attribute naming the engineer is unknown.
+func teamEngineerEntry(ctx context.Context, element attr.Value) (EngineerTFModel, bool, diag.Diagnostics) {
+	var entry EngineerTFModel
+
+	object, ok := element.(types.Object)
+	if !ok || object.IsNull() || object.IsUnknown() {
+		return entry, false, nil
+	}
+
+	diags := object.As(ctx, &entry, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return entry, false, diags
+	}
+
+	if entry.Id.IsUnknown() || (entry.Id.IsNull() && (entry.Email.IsNull() || entry.Email.IsUnknown())) {
+		return entry, false, diags
+	}
+
+	return entry, true, diags
+}
+
+// verifyEngineersExist looks up every known entry in a team's engineers set,
+// as configured, and returns an attribute error for each engineer the backend
+// does not know. Entries naming an engineer by email and name are allowed to
+// be missing when createMissing is set, since they will be created on apply.
+// Unknown entries, such as those of engineers created in the same apply, are
+// skipped.
+func verifyEngineersExist(ctx context.Context, client *DevopsClient, engineers types.Set, engineersPath path.Path, createMissing bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if engineers.IsNull() || engineers.IsUnknown() {
+		return diags
+	}
+
+	lookup := &teamEngineerLookup{client: client}
+	checked := map[string]bool{}
+
+	for _, element := range engineers.Elements() {
+		entry, ok, entryDiags := teamEngineerEntry(ctx, element)
+		diags.Append(entryDiags...)
+
+		if !ok || checked[entry.Id.ValueString()+"/"+entry.Email.ValueString()] {
+			continue
+		}
+		checked[entry.Id.ValueString()+"/"+entry.Email.ValueString()] = true
+
+		_, found, err := lookup.find(ctx, entry)
+		if err != nil {
+			diags.AddAttributeError(teamEngineerPath(engineersPath, element, entry), "Client Error", fmt.Sprintf("Unable to get engineer %s, got error: %s", describeTeamEngineer(entry), err))
+			continue
+		}
+
+		if found {
+			continue
+		}
+
+		switch {
+		case !entry.Id.IsNull() || !createMissing:
+			diags.Append(engineerNotFound(engineersPath, element, entry))
+		case entry.Name.IsNull():
+			diags.Append(engineerNameRequired(engineersPath, element, entry))
+		}
+	}
+
+	return diags
+}
+
+// resolveTeamEngineers returns the backend engineers for a team's engineers
+// set, as configured. Entries naming a missing engineer by email are created
+// from their name and email when createMissing is set; any other missing
+// engineer is an attribute error on its entry.
+func resolveTeamEngineers(ctx context.Context, client *DevopsClient, engineers types.Set, engineersPath path.Path, createMissing bool) ([]EngineerAPIModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var resolved []EngineerAPIModel
+
+	if engineers.IsNull() || engineers.IsUnknown() {
+		return resolved, diags
+	}
+
+	lookup := &teamEngineerLookup{client: client}
+
+	for _, element := range engineers.Elements() {
+		entry, ok, entryDiags := teamEngineerEntry(ctx, element)
+		diags.Append(entryDiags...)
+
+		if !ok {
+			diags.AddAttributeError(engineersPath.AtSetValue(element), "Unknown Engineer", "The engineer entry is still unknown at apply time.")
+			continue
+		}
+
+		entryPath := teamEngineerPath(engineersPath, element, entry)
+
+		engineer, found, err := lookup.find(ctx, entry)
+		if err != nil {
+			diags.AddAttributeError(entryPath, "Client Error", fmt.Sprintf("Unable to get engineer %s, got error: %s", describeTeamEngineer(entry), err))
+			continue
+		}
+
+		if found {
+			if !entry.Name.IsNull() && !entry.Name.IsUnknown() && entry.Name.ValueString() != engineer.Name {
+				diags.AddAttributeError(
+					engineersPath.AtSetValue(element).AtName("name"),
+					"Engineer Name Mismatch",
+					fmt.Sprintf("The engineer with email %s is named %q, not %q. Update the name or remove it from the entry.", engineer.Email, engineer.Name, entry.Name.ValueString()),
+				)
+				continue
+			}
+
+			resolved = append(resolved, engineer)
+			continue
+		}
+
+		if !entry.Id.IsNull() || !createMissing {
+			diags.Append(engineerNotFound(engineersPath, element, entry))
+			continue
+		}
+
+		if entry.Name.IsNull() || entry.Name.IsUnknown() {
+			diags.Append(engineerNameRequired(engineersPath, element, entry))
+			continue
+		}
+
+		created, err := client.CreateEngineer(ctx, EngineerAPIModel{
+			Name:  entry.Name.ValueString(),
+			Email: entry.Email.ValueString(),
+		})
+		if err != nil {
+			diags.AddAttributeError(entryPath, "Client Error", fmt.Sprintf("Unable to create engineer %s, got error: %s", describeTeamEngineer(entry), err))
+			continue
+		}
+
+		// Later entries with the same email refer to the new engineer
+		lookup.byEmail[normalizeEmail(created.Email)] = []EngineerAPIModel{created}
+
+		resolved = append(resolved, created)
+	}
+
+	return resolved, diags
+}
+
+// teamEngineerPath returns the path of the attribute that names the engineer
+// of an entry.
+func teamEngineerPath(engineersPath path.Path, element attr.Value, entry EngineerTFModel) path.Path {
+	if entry.Id.IsNull() {
+		return engineersPath.AtSetValue(element).AtName("email")
+	}
+
+	return engineersPath.AtSetValue(element).AtName("id")
+}
+
+// describeTeamEngineer names the engineer of an entry in messages.
+func describeTeamEngineer(entry EngineerTFModel) string {
+	if entry.Id.IsNull() {
+		return entry.Email.ValueString()
+	}
+
+	return entry.Id.ValueString()
+}
+
+// engineerNotFound returns the error for an entry naming a missing engineer.
+func engineerNotFound(engineersPath path.Path, element attr.Value, entry EngineerTFModel) diag.Diagnostic {
+	entryPath := teamEngineerPath(engineersPath, element, entry)
+
+	if entry.Id.IsNull() {
+		return diag.NewAttributeErrorDiagnostic(
+			entryPath,
+			"Engineer Not Found",
+			fmt.Sprintf("No engineer has email %s. Create the engineer first, or set create_missing_engineers = true "+
+				"to create it from the name and email of this entry.", entry.Email.ValueString()),
+		)
+	}
+
+	return diag.NewAttributeErrorDiagnostic(
+		entryPath,
+		"Engineer Not Found",
+		fmt.Sprintf("Engineer %s does not exist. Create the engineer first or remove it from the team.", entry.Id.ValueString()),
+	)
+}
+
+// engineerNameRequired returns the error for a missing engineer that cannot be
+// created because its entry has no name.
+func engineerNameRequired(engineersPath path.Path, element attr.Value, entry EngineerTFModel) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		engineersPath.AtSetValue(element).AtName("name"),
+		"Missing Engineer Name",
+		fmt.Sprintf("No engineer has email %s, and it cannot be created without a name. Set the name of this entry.", entry.Email.ValueString()),
+	)
+}
+
+// validateEngineerReferences returns an attribute error for every entry in a
+// team's engineers set that does not name its engineer unambiguously: each
+// entry needs either an id, or an email with an optional name.
+func validateEngineerReferences(ctx context.Context, engineers types.Set, engineersPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if engineers.IsNull() || engineers.IsUnknown() {
+		return diags
+	}
+
+	for _, element := range engineers.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		var entry EngineerTFModel
+
+		if entryDiags := object.As(ctx, &entry, basetypes.ObjectAsOptions{}); entryDiags.HasError() {
+			diags.Append(entryDiags...)
+			return diags
+		}
+
+		switch {
+		case !entry.Id.IsNull() && (!entry.Name.IsNull() || !entry.Email.IsNull()):
+			diags.AddAttributeError(
+				engineersPath.AtSetValue(element),
+				"Conflicting Engineer Attributes",
+				"An engineer entry with an id takes its name and email from the engineer. Set either id, or email with an optional name.",
+			)
+		case entry.Id.IsNull() && entry.Email.IsNull():
+			diags.AddAttributeError(
+				engineersPath.AtSetValue(element),
+				"Missing Engineer Reference",
+				"Each engineer entry needs an id, or an email to look the engineer up by.",
+			)
+		case entry.Id.IsNull() && !entry.Email.IsUnknown():
+			if err := validateEmail(entry.Email.ValueString()); err != nil {
+				diags.AddAttributeError(engineersPath.AtSetValue(element).AtName("email"), "Invalid Email", fmt.Sprintf("Invalid engineer email, got error: %s", err))
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateUniqueEngineers returns an attribute error for every engineer ID
+// listed more than once in a team's engineers set. Terraform already merges
+// entries that are identical, so this catches entries that share an ID but
+// differ in another attribute.
+func validateUniqueEngineers(engineers types.Set, engineersPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if engineers.IsNull() || engineers.IsUnknown() {
+		return diags
+	}
+
+	seen := map[string]bool{}
+
+	for _, element := range engineers.Elements() {
+		engineer, ok := element.(types.Object)
+		if !ok || engineer.IsUnknown() {
+			continue
+		}
+
+		id, ok := engineer.Attributes()["id"].(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+
+		if seen[id.ValueString()] {
+			diags.AddAttributeError(
+				engineersPath.AtSetValue(element).AtName("id"),
+				"Duplicate Engineer",
+				fmt.Sprintf("Engineer %s is listed more than once. Each engineer can only be on a team once.", id.ValueString()),
+			)
+			continue
+		}
+		seen[id.ValueString()] = true
+	}
+
+	return diags
+}
+
+// diffTeamEngineerIDs compares the engineers of a team in prior state with the
+// engineers it should have, and returns the IDs to add and to remove.
+func diffTeamEngineerIDs(prior []EngineerTFModel, desired []EngineerAPIModel) ([]string, []string) {
+	var added, removed []string
+
+	priorIDs := map[string]bool{}
+	for _, engineer := range prior {
+		priorIDs[engineer.Id.ValueString()] = true
+	}
+
+	desiredIDs := map[string]bool{}
+	for _, engineer := range desired {
+		if desiredIDs[engineer.Id] {
+			continue
+		}
+		desiredIDs[engineer.Id] = true
+
+		if !priorIDs[engineer.Id] {
+			added = append(added, engineer.Id)
+		}
+	}
+
+	for _, engineer := range prior {
+		if id := engineer.Id.ValueString(); !desiredIDs[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	return added, removed
+}
+
+// applyTeamMemberChanges adds and removes team engineers through the member
+// endpoints. Removals go first, so a failure part way through does not leave
+// the team with more members than either roster.
+func applyTeamMemberChanges(ctx context.Context, client *DevopsClient, teamPath, teamID string, added, removed []string) error {
+	for _, id := range removed {
+		if err := client.RemoveTeamMember(ctx, teamPath, teamID, id); err != nil {
+			return fmt.Errorf("unable to remove engineer %s: %w", id, err)
+		}
+	}
+
+	for _, id := range added {
+		if err := client.AddTeamMember(ctx, teamPath, teamID, id); err != nil {
+			return fmt.Errorf("unable to add engineer %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// membershipDriftWarning compares the engineers of a team in prior state with
+// the engineers the backend reports, and returns a warning naming everyone
+// added or removed outside Terraform. It returns false when nothing changed or
+// there is no prior membership to compare with, such as right after import.
+func membershipDriftWarning(kind, teamName string, prior []EngineerTFModel, refreshed []EngineerAPIModel) (diag.Diagnostic, bool) {
+	if prior == nil {
+		return nil, false
+	}
+
+	described := map[string]string{}
+	before := make([]rosterMember, 0, len(prior))
+	for _, engineer := range prior {
+		id := engineer.Id.ValueString()
+		before = append(before, rosterMember{Id: id})
+		described[id] = describeEngineer(id, engineer.Name.ValueString(), engineer.Email.ValueString())
+	}
+
+	after := make([]rosterMember, 0, len(refreshed))
+	for _, engineer := range refreshed {
+		after = append(after, rosterMember{Id: engineer.Id})
+		described[engineer.Id] = describeEngineer(engineer.Id, engineer.Name, engineer.Email)
+	}
+
+	diff := diffRosters(before, after)
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 {
+		return nil, false
+	}
+
+	var lines []string
+	for _, id := range diff.Added {
+		lines = append(lines, "  + "+described[id])
+	}
+	for _, id := range diff.Removed {
+		lines = append(lines, "  - "+described[id])
+	}
+
+	return diag.NewWarningDiagnostic(
+		"Team Membership Changed Outside Terraform",
+		fmt.Sprintf("The engineers of %s %q were changed outside of Terraform:\n\n%s\n\n"+
+			"Apply to restore the configured engineers, or update the configuration to keep these changes.", kind, teamName, strings.Join(lines, "\n")),
+	), true
+}
+
+// describeEngineer names an engineer in messages.
+func describeEngineer(id, name, email string) string {
+	if name == "" && email == "" {
+		return id
+	}
+
+	return fmt.Sprintf("%s <%s> (%s)", name, email, id)
+}
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testEngineerAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"id":    types.StringType,
+	"email": EmailType{},
+}
+
+func testEngineerSet(t *testing.T, ids ...types.String) types.Set {
+	elements := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		elements = append(elements, types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+			"name":  types.StringNull(),
+			"id":    id,
+			"email": NewEmailNull(),
+		}))
+	}
+
+	set, diags := types.SetValue(types.ObjectType{AttrTypes: testEngineerAttrTypes}, elements)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return set
+}
+
+func TestVerifyEngineersExist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/engineers/id/H3ZTR" {
+			_, _ = w.Write([]byte(`{"id":"H3ZTR","name":"Ryan","email":"ryan@ferrets.com"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	engineers := testEngineerSet(t, types.StringValue("H3ZTR"), types.StringValue("ZZZZZ"), types.StringUnknown())
+
+	diags := verifyEngineersExist(context.Background(), client, engineers, path.Root("engineers"), false)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got: %v", diags)
+	}
+
+	if !strings.Contains(diags.Errors()[0].Detail(), "ZZZZZ") {
+		t.Errorf("expected the error to name the missing engineer, got: %s", diags.Errors()[0].Detail())
+	}
+}
+
+// testEngineerByEmail returns an engineers set entry naming an engineer by
+// email, with an optional name.
+func testEngineerByEmail(name, email string) attr.Value {
+	nameValue := types.StringNull()
+	if name != "" {
+		nameValue = types.StringValue(name)
+	}
+
+	return types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+		"name":  nameValue,
+		"id":    types.StringNull(),
+		"email": NewEmailValue(email),
+	})
+}
+
+// newTeamMembersServer serves GET /engineers from engineers and records the
+// engineers created with POST /engineers.
+func newTeamMembersServer(t *testing.T, engineers []EngineerAPIModel, created *[]EngineerAPIModel) *httptest.Server {
+	errs := newHandlerErrors(t)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/engineers":
+			_ = json.NewEncoder(w).Encode(engineers)
+		case r.Method == http.MethodPost && r.URL.Path == "/engineers":
+			var engineer EngineerAPIModel
+			if err := json.NewDecoder(r.Body).Decode(&engineer); err != nil {
+				errs.fail(w, fmt.Errorf("invalid engineer: %w", err))
+				return
+			}
+			engineer.Id = idFromSeed(engineer.Email)
+			*created = append(*created, engineer)
+			_ = json.NewEncoder(w).Encode(engineer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestVerifyEngineersExistByEmail(t *testing.T) {
+	var created []EngineerAPIModel
+	server := newTeamMembersServer(t, []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}, &created)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	engineers := types.SetValueMust(types.ObjectType{AttrTypes: testEngineerAttrTypes}, []attr.Value{
+		testEngineerByEmail("", "Ryan@Ferrets.com"),
+		testEngineerByEmail("", "wick@google.com"),
+		testEngineerByEmail("grant", "grant@google.com"),
+	})
+
+	diags := verifyEngineersExist(context.Background(), client, engineers, path.Root("engineers"), false)
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors without create_missing_engineers, got: %v", diags)
+	}
+
+	diags = verifyEngineersExist(context.Background(), client, engineers, path.Root("engineers"), true)
+
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Missing Engineer Name" {
+		t.Fatalf("expected only the entry without a name to fail, got: %v", diags)
+	}
+
+	if len(created) != 0 {
+		t.Errorf("expected nothing to be created at plan time, got %v", created)
+	}
+}
+
+func TestResolveTeamEngineersCreatesMissing(t *testing.T) {
+	var created []EngineerAPIModel
+	server := newTeamMembersServer(t, []EngineerAPIModel{{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"}}, &created)
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+	engineers := types.SetValueMust(types.ObjectType{AttrTypes: testEngineerAttrTypes}, []attr.Value{
+		testEngineerByEmail("", "ryan@ferrets.com"),
+		testEngineerByEmail("grant", "grant@google.com"),
+	})
+
+	resolved, diags := resolveTeamEngineers(context.Background(), client, engineers, path.Root("engineers"), true)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(resolved) != 2 || len(created) != 1 || created[0].Name != "grant" {
+		t.Fatalf("expected grant to be created and both engineers resolved, got resolved %v and created %v", resolved, created)
+	}
+
+	_, diags = resolveTeamEngineers(context.Background(), client, engineers, path.Root("engineers"), false)
+
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), "create_missing_engineers") {
+		t.Errorf("expected an error pointing at create_missing_engineers, got: %v", diags)
+	}
+}
+
+func TestValidateEngineerReferences(t *testing.T) {
+	engineers := types.SetValueMust(types.ObjectType{AttrTypes: testEngineerAttrTypes}, []attr.Value{
+		types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue("Ryan"),
+			"id":    types.StringValue("H3ZTR"),
+			"email": NewEmailNull(),
+		}),
+		types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue("grant"),
+			"id":    types.StringNull(),
+			"email": NewEmailNull(),
+		}),
+		testEngineerByEmail("wick", "not-an-email"),
+		testEngineerByEmail("", "ryan@ferrets.com"),
+	})
+
+	diags := validateEngineerReferences(context.Background(), engineers, path.Root("engineers"))
+
+	summaries := map[string]bool{}
+	for _, d := range diags.Errors() {
+		summaries[d.Summary()] = true
+	}
+
+	if diags.ErrorsCount() != 3 || !summaries["Conflicting Engineer Attributes"] || !summaries["Missing Engineer Reference"] || !summaries["Invalid Email"] {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValidateUniqueEngineers(t *testing.T) {
+	engineer := func(id, name string) attr.Value {
+		return types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(name),
+			"id":    types.StringValue(id),
+			"email": NewEmailNull(),
+		})
+	}
+
+	engineers := types.SetValueMust(types.ObjectType{AttrTypes: testEngineerAttrTypes}, []attr.Value{
+		engineer("H3ZTR", "Ryan"),
+		engineer("H3ZTR", "ryan"),
+		engineer("POE5O", "grant"),
+	})
+
+	diags := validateUniqueEngineers(engineers, path.Root("engineers"))
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got: %v", diags)
+	}
+
+	if !strings.Contains(diags.Errors()[0].Detail(), "H3ZTR") {
+		t.Errorf("expected the error to name the duplicate engineer, got: %s", diags.Errors()[0].Detail())
+	}
+}
+
+func TestDiffTeamEngineerIDs(t *testing.T) {
+	prior := []EngineerTFModel{
+		{Id: types.StringValue("H3ZTR")},
+		{Id: types.StringValue("POE5O")},
+	}
+	desired := []EngineerAPIModel{{Id: "POE5O"}, {Id: "K9LMQ"}, {Id: "K9LMQ"}}
+
+	added, removed := diffTeamEngineerIDs(prior, desired)
+
+	if strings.Join(added, ",") != "K9LMQ" || strings.Join(removed, ",") != "H3ZTR" {
+		t.Errorf("expected K9LMQ added and H3ZTR removed, got %v and %v", added, removed)
+	}
+}
+
+func TestApplyTeamMemberChanges(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(server.Client(), server.URL)
+
+	if err := applyTeamMemberChanges(context.Background(), client, "/op", "OPS01", []string{"K9LMQ"}, []string{"H3ZTR"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "DELETE /op/OPS01/engineers/H3ZTR,POST /op/OPS01/engineers/K9LMQ"
+	if got := strings.Join(requests, ","); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMembershipDriftWarning(t *testing.T) {
+	prior := []EngineerTFModel{
+		{Id: types.StringValue("H3ZTR"), Name: types.StringValue("Ryan"), Email: NewEmailValue("ryan@ferrets.com")},
+		{Id: types.StringValue("POE5O"), Name: types.StringValue("grant"), Email: NewEmailValue("grant@google.com")},
+	}
+
+	if _, ok := membershipDriftWarning("ops team", "platform", prior, []EngineerAPIModel{
+		{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		{Id: "H3ZTR", Name: "Ryan", Email: "ryan@ferrets.com"},
+	}); ok {
+		t.Error("expected no warning when membership is unchanged")
+	}
+
+	if _, ok := membershipDriftWarning("ops team", "platform", nil, []EngineerAPIModel{{Id: "H3ZTR"}}); ok {
+		t.Error("expected no warning without prior membership")
+	}
+
+	warning, ok := membershipDriftWarning("ops team", "platform", prior, []EngineerAPIModel{
+		{Id: "POE5O", Name: "grant", Email: "grant@google.com"},
+		{Id: "K9LMQ", Name: "wick", Email: "wick@google.com"},
+	})
+
+	if !ok || warning.Severity() != diag.SeverityWarning {
+		t.Fatalf("expected a warning, got %v", warning)
+	}
+
+	for _, want := range []string{"+ wick <wick@google.com> (K9LMQ)", "- Ryan <ryan@ferrets.com> (H3ZTR)", `ops team "platform"`} {
+		if !strings.Contains(warning.Detail(), want) {
+			t.Errorf("expected the warning to contain %q, got %q", want, warning.Detail())
+		}
+	}
+
+	if strings.Contains(warning.Detail(), "POE5O") {
+		t.Errorf("expected unchanged engineers to be left out, got %q", warning.Detail())
+	}
+}
+
+func TestUsePriorEngineers(t *testing.T) {
+	ctx := context.Background()
+	elementType := types.ObjectType{AttrTypes: testEngineerAttrTypes}
+
+	ryan := types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+		"name":  types.StringValue("Ryan"),
+		"id":    types.StringValue("H3ZTR"),
+		"email": NewEmailValue("ryan@ferrets.com"),
+	})
+	grant := types.ObjectValueMust(testEngineerAttrTypes, map[string]attr.Value{
+		"name":  types.StringValue("grant"),
+		"id":    types.StringValue("POE5O"),
+		"email": NewEmailValue("grant@google.com"),
+	})
+
+	req := planmodifier.SetRequest{
+		StateValue: types.SetValueMust(elementType, []attr.Value{ryan, grant}),
+		ConfigValue: types.SetValueMust(elementType, []attr.Value{
+			testEngineerSet(t, types.StringValue("H3ZTR")).Elements()[0],
+			testEngineerByEmail("Grant G", " Grant@Google.com"),
+			testEngineerByEmail("", "wick@google.com"),
+		}),
+	}
+	resp := &planmodifier.SetResponse{PlanValue: req.ConfigValue}
+
+	usePriorEngineers().PlanModifySet(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	planned := resp.PlanValue.Elements()
+	if len(planned) != 3 {
+		t.Fatalf("expected 3 planned engineers, got %d", len(planned))
+	}
+
+	var unknown int
+	for _, element := range planned {
+		switch {
+		case element.Equal(ryan), element.Equal(grant):
+		case element.(types.Object).Attributes()["id"].IsUnknown():
+			unknown++
+		default:
+			t.Errorf("unexpected planned engineer %s", element)
+		}
+	}
+
+	if unknown != 1 {
+		t.Errorf("expected only the new address to be planned as a new engineer, got %d", unknown)
+	}
+
+	if !containsValue(planned, ryan) || !containsValue(planned, grant) {
+		t.Errorf("expected Ryan and grant to keep their prior values, got %s", resp.PlanValue)
+	}
+
+	// An unchanged configuration plans exactly the prior state
+	req.ConfigValue = types.SetValueMust(elementType, []attr.Value{
+		testEngineerSet(t, types.StringValue("H3ZTR")).Elements()[0],
+		testEngineerByEmail("", "grant@google.com"),
+	})
+	resp = &planmodifier.SetResponse{PlanValue: req.ConfigValue}
+
+	usePriorEngineers().PlanModifySet(ctx, req, resp)
+
+	if !resp.PlanValue.Equal(req.StateValue) {
+		t.Errorf("expected the plan to equal prior state, got %s", resp.PlanValue)
+	}
+}
+
+// containsValue reports whether values contains value.
+func containsValue(values []attr.Value, value attr.Value) bool {
+	for _, v := range values {
+		if v.Equal(value) {
+			return true
+		}
+	}
+
+	return false
+}
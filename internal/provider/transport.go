@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// readOnlyTransport refuses every request that could change the backend. It
+// backs up the checks resources make in read-only mode, so a missed check
+// fails instead of writing.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+
+		return nil, fmt.Errorf("refusing %s %s: the provider is configured with read_only = true", req.Method, req.URL.Redacted())
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// readOnlyHTTPClient returns a copy of httpClient whose transport refuses
+// requests other than GET and HEAD.
+func readOnlyHTTPClient(httpClient *http.Client) *http.Client {
+	clone := *httpClient
+
+	base := clone.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	clone.Transport = readOnlyTransport{base: base}
+
+	return &clone
+}
+
+// readOnlyError returns the error for a write attempted while the provider is
+// read-only.
+func readOnlyError(action, kind string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Unable to %s %s: the provider is configured with read_only = true, so no changes are made to the backend. "+
+			"Remove read_only from the provider configuration to manage resources.", action, kind),
+	)
+}
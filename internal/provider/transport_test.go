@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestReadOnlyHTTPClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+	}))
+	defer server.Close()
+
+	client := readOnlyHTTPClient(server.Client())
+
+	resp, err := client.Get(server.URL + "/engineers")
+	if err != nil {
+		t.Fatalf("expected GET to be allowed, got error: %s", err)
+	}
+	resp.Body.Close()
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, _ := http.NewRequest(method, server.URL+"/engineers", strings.NewReader("{}"))
+
+		if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "read_only") {
+			t.Errorf("expected %s to be refused, got error: %v", method, err)
+		}
+	}
+
+	if strings.Join(requests, ",") != http.MethodGet {
+		t.Errorf("expected only the GET to reach the backend, got %v", requests)
+	}
+
+	if server.Client().Transport == client.Transport {
+		t.Error("expected the original client to be left unchanged")
+	}
+}
+
+func TestReadOnlyResourcesRefuseWrites(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewDevopsClient(readOnlyHTTPClient(server.Client()), server.URL)
+	client.ReadOnly = true
+
+	ctx := context.Background()
+
+	for _, r := range []resource.Resource{&EngineerResource{client: client}, &OpsResource{client: client}} {
+		createResp := &resource.CreateResponse{}
+		r.Create(ctx, resource.CreateRequest{}, createResp)
+
+		updateResp := &resource.UpdateResponse{}
+		r.Update(ctx, resource.UpdateRequest{}, updateResp)
+
+		deleteResp := &resource.DeleteResponse{}
+		r.Delete(ctx, resource.DeleteRequest{}, deleteResp)
+
+		for _, diags := range [][]string{
+			summaries(createResp.Diagnostics.Errors()),
+			summaries(updateResp.Diagnostics.Errors()),
+			summaries(deleteResp.Diagnostics.Errors()),
+		} {
+			if strings.Join(diags, ",") != "Provider Is Read-Only" {
+				t.Errorf("%T: expected a read-only error, got %v", r, diags)
+			}
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no requests, got %d", requests)
+	}
+}
+
+// summaries returns the summary of every diagnostic.
+func summaries(diags diag.Diagnostics) []string {
+	var result []string
+	for _, d := range diags {
+		result = append(result, d.Summary())
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidEmailFunction{}
+
+func NewValidEmailFunction() function.Function {
+	return &ValidEmailFunction{}
+}
+
+// ValidEmailFunction defines the function implementation.
+type ValidEmailFunction struct{}
+
+func (f *ValidEmailFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "valid_email"
+}
+
+func (f *ValidEmailFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check an engineer email",
+		MarkdownDescription: "Returns true if the email passes the same RFC 5322-lite rules the provider applies to engineer emails: " +
+			"a dot-atom local part of at most 64 characters, a host name with an alphabetic top level domain and at most 254 characters overall. " +
+			"Surrounding whitespace is ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "email",
+				MarkdownDescription: "Email to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidEmailFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &email))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, validateEmail(email) == nil))
+}
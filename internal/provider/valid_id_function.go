@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidIDFunction{}
+
+func NewValidIDFunction() function.Function {
+	return &ValidIDFunction{}
+}
+
+// ValidIDFunction defines the function implementation.
+type ValidIDFunction struct{}
+
+func (f *ValidIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "valid_id"
+}
+
+func (f *ValidIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check an engineer or team ID",
+		MarkdownDescription: "Returns true if the ID has the backend format of five uppercase letters or digits, such as `H3ZTR`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "ID to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, validateID(id) == nil))
+}
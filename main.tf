@@ -25,57 +25,88 @@
 #   value = data.devops-bootcamp_devops.devops_test
 # }
 
-resource "devops-bootcamp_engineer-resource" "grant" {
+resource "devops-bootcamp_engineer" "grant" {
   name  = "grant"
   email = "grant@google.com"
 }
 
-resource "devops-bootcamp_engineer-resource" "jocko" {
+resource "devops-bootcamp_engineer" "jocko" {
   name  = "jocko"
   email = "jocko@google.com"
 }
 
-resource "devops-bootcamp_engineer-resource" "ben" {
+resource "devops-bootcamp_engineer" "ben" {
   name  = "ben"
   email = "ben@google.com"
 }
 
-resource "devops-bootcamp_engineer-resource" "myles" {
+resource "devops-bootcamp_engineer" "myles" {
   name = "myles"
   email = "myles@google.com"
 }
-resource "devops-bootcamp_engineer-resource" "wick" {
+resource "devops-bootcamp_engineer" "wick" {
   name = "wick"
   email = "wick@google.com"
 }
 
+# Move state recorded under the engineer-resource type name
+moved {
+  from = devops-bootcamp_engineer-resource.grant
+  to   = devops-bootcamp_engineer.grant
+}
+
+moved {
+  from = devops-bootcamp_engineer-resource.jocko
+  to   = devops-bootcamp_engineer.jocko
+}
+
+moved {
+  from = devops-bootcamp_engineer-resource.ben
+  to   = devops-bootcamp_engineer.ben
+}
+
+moved {
+  from = devops-bootcamp_engineer-resource.myles
+  to   = devops-bootcamp_engineer.myles
+}
+
+moved {
+  from = devops-bootcamp_engineer-resource.wick
+  to   = devops-bootcamp_engineer.wick
+}
+
+
+# moved {
+#   from = devops-bootcamp_ops-resource.example
+#   to   = devops-bootcamp_ops_team.example
+# }
 
-# resource "devops-bootcamp_ops-resource" "example" {
+# resource "devops-bootcamp_ops_team" "example" {
 #   name = "example-ops-2"
 #   engineers = [
 #     {
-#       id = devops-bootcamp_engineer-resource.grant.id
+#       id = devops-bootcamp_engineer.grant.id
 #     },
 #     {
-#       id = devops-bootcamp_engineer-resource.jocko.id
+#       id = devops-bootcamp_engineer.jocko.id
 #     },
 #     {
-#      id = devops-bootcamp_engineer-resource.myles.id
+#      id = devops-bootcamp_engineer.myles.id
 #     },
 #     {
-#       id = devops-bootcamp_engineer-resource.ben.id
+#       id = devops-bootcamp_engineer.ben.id
 #     },
 #     # {
-#     #   id = devops-bootcamp_engineer-resource.wick.id
+#     #   id = devops-bootcamp_engineer.wick.id
 #     # }
 #   ]
 
 # }
 
 # output "example_engineer" {
-#   value = devops-bootcamp_engineer-resource.example
+#   value = devops-bootcamp_engineer.example
 # }
 
 # output "example_engineer-2" {
-#   value = devops-bootcamp_engineer-resource.example-2
+#   value = devops-bootcamp_engineer.example-2
 # }
\ No newline at end of file
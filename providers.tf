@@ -7,5 +7,5 @@ terraform {
  }
 
 provider "devops-bootcamp" {
-    endpoint =  "http://localhost:8080/devops"
+    endpoint =  "http://localhost:8080"
 }